@@ -0,0 +1,141 @@
+// Package aliases manages user defined names for the language versions,
+// like "legacy" for "6.11.5", every alias is stored as a separate file
+package aliases
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+var (
+	rName = regexp.MustCompile(`^[a-zA-Z][\w.-]*$`)
+
+	// reserved are the names which already mean something for eclectica
+	reserved = []string{"current", "latest", versions.System}
+)
+
+// Set points alias of the language to the provided version
+func Set(language, name, version string) error {
+	err := validate(name)
+	if err != nil {
+		return err
+	}
+
+	if version != versions.System {
+		if _, errParse := versions.Parse(version); errParse != nil {
+			return errors.New("\"" + version + "\" is not a version")
+		}
+	}
+
+	_, err = io.CreateDir(variables.Aliases(language))
+	if err != nil {
+		return err
+	}
+
+	return io.WriteFile(path(language, name), version)
+}
+
+// Get gets version the alias of the language points to,
+// empty string is returned if there is no such alias
+func Get(language, name string) string {
+	if rName.MatchString(name) == false {
+		return ""
+	}
+
+	return strings.TrimSpace(io.Read(path(language, name)))
+}
+
+// Resolve gets version the value points to if it's the alias,
+// otherwise value is returned as is
+func Resolve(language, value string) string {
+	if version := Get(language, value); version != "" {
+		return version
+	}
+
+	return value
+}
+
+// Remove removes alias of the language
+func Remove(language, name string) error {
+	if Get(language, name) == "" {
+		return errors.New("Alias \"" + name + "\" for " + language + " is not defined")
+	}
+
+	err := os.Remove(path(language, name))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// List gets all aliases of the language with versions they point to
+func List(language string) (map[string]string, error) {
+	result := map[string]string{}
+
+	files, err := ioutil.ReadDir(variables.Aliases(language))
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, file := range files {
+		if version := Get(language, file.Name()); version != "" {
+			result[file.Name()] = version
+		}
+	}
+
+	return result, nil
+}
+
+// Names gets sorted alias names for every version of the language
+func Names(language string) map[string][]string {
+	result := map[string][]string{}
+
+	list, _ := List(language)
+	for name, version := range list {
+		result[version] = append(result[version], name)
+	}
+
+	for version := range result {
+		sort.Strings(result[version])
+	}
+
+	return result
+}
+
+// validate checks if name could be used as the alias
+func validate(name string) error {
+	if rName.MatchString(name) == false {
+		return errors.New("\"" + name + "\" could not be used as an alias, it should start with a letter")
+	}
+
+	if _, err := versions.Parse(name); err == nil {
+		return errors.New("\"" + name + "\" could not be used as an alias, since it's a version")
+	}
+
+	for _, elem := range reserved {
+		if elem == name {
+			return errors.New("\"" + name + "\" could not be used as an alias, since it's reserved")
+		}
+	}
+
+	return nil
+}
+
+// path gets path to the alias file
+func path(language, name string) string {
+	return filepath.Join(variables.Aliases(language), name)
+}
@@ -0,0 +1,161 @@
+package aliases_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("aliases", func() {
+	var home string
+
+	BeforeEach(func() {
+		home = filepath.Join(os.TempDir(), "eclectica-aliases-home")
+
+		os.Setenv("EC_HOME", home)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_HOME")
+		os.RemoveAll(home)
+	})
+
+	Describe("Set", func() {
+		It("should store alias under the language folder", func() {
+			err := Set("node", "legacy", "6.11.5")
+
+			Expect(err).To(BeNil())
+			Expect(filepath.Join(variables.Aliases("node"), "legacy")).To(BeAnExistingFile())
+		})
+
+		It("should redefine existing alias", func() {
+			Set("node", "legacy", "6.11.5")
+			Set("node", "legacy", "4.8.7")
+
+			Expect(Get("node", "legacy")).To(Equal("4.8.7"))
+		})
+
+		It("should allow masks and system version", func() {
+			Expect(Set("node", "work", "8")).To(BeNil())
+			Expect(Set("node", "ci", "system")).To(BeNil())
+		})
+
+		It("should not allow versions as names", func() {
+			err := Set("node", "v6", "6.11.5")
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("since it's a version"))
+		})
+
+		It("should not allow reserved names", func() {
+			for _, name := range []string{"current", "latest", "system"} {
+				err := Set("node", name, "6.11.5")
+
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("since it's reserved"))
+			}
+		})
+
+		It("should not allow paths as names", func() {
+			Expect(Set("node", "../legacy", "6.11.5")).NotTo(BeNil())
+			Expect(Set("node", "", "6.11.5")).NotTo(BeNil())
+		})
+
+		It("should not allow things which are not versions", func() {
+			err := Set("node", "legacy", "old")
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("is not a version"))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get version of the alias", func() {
+			Set("node", "legacy", "6.11.5")
+
+			Expect(Get("node", "legacy")).To(Equal("6.11.5"))
+		})
+
+		It("should not mix languages", func() {
+			Set("node", "legacy", "6.11.5")
+
+			Expect(Get("go", "legacy")).To(Equal(""))
+		})
+
+		It("should return empty string for unknown alias", func() {
+			Expect(Get("node", "legacy")).To(Equal(""))
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should resolve alias", func() {
+			Set("node", "legacy", "6.11.5")
+
+			Expect(Resolve("node", "legacy")).To(Equal("6.11.5"))
+		})
+
+		It("should return versions as is", func() {
+			Set("node", "legacy", "6.11.5")
+
+			Expect(Resolve("node", "8.9.0")).To(Equal("8.9.0"))
+			Expect(Resolve("node", "current")).To(Equal("current"))
+		})
+	})
+
+	Describe("Remove", func() {
+		It("should remove alias", func() {
+			Set("node", "legacy", "6.11.5")
+
+			Expect(Remove("node", "legacy")).To(BeNil())
+			Expect(Get("node", "legacy")).To(Equal(""))
+		})
+
+		It("should return error for unknown alias", func() {
+			err := Remove("node", "legacy")
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("Alias \"legacy\" for node is not defined"))
+		})
+	})
+
+	Describe("List", func() {
+		It("should list aliases of the language", func() {
+			Set("node", "legacy", "6.11.5")
+			Set("node", "work", "8.9.0")
+			Set("go", "legacy", "1.8.3")
+
+			list, err := List("node")
+
+			Expect(err).To(BeNil())
+			Expect(list).To(Equal(map[string]string{
+				"legacy": "6.11.5",
+				"work":   "8.9.0",
+			}))
+		})
+
+		It("should return empty list if there is no aliases", func() {
+			list, err := List("node")
+
+			Expect(err).To(BeNil())
+			Expect(list).To(BeEmpty())
+		})
+	})
+
+	Describe("Names", func() {
+		It("should group aliases by versions", func() {
+			Set("node", "work", "6.11.5")
+			Set("node", "legacy", "6.11.5")
+			Set("node", "ci", "8.9.0")
+
+			Expect(Names("node")).To(Equal(map[string][]string{
+				"6.11.5": {"legacy", "work"},
+				"8.9.0":  {"ci"},
+			}))
+		})
+	})
+})
@@ -9,9 +9,9 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/markelog/eclectica/cmd/events"
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/console"
-	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
@@ -20,11 +20,18 @@ import (
 // Pipe results of command execution to parent and
 // pass environment variables from language plugin
 func setCmd(cmd *exec.Cmd, language, version string) {
-	environment, err := plugins.New(&plugins.Args{
-		Language: language,
-		Version:  version,
-	}).Environment()
-	print.Error(err)
+	var environment []string
+
+	// System version doesn't need anything from us
+	if version != versions.System {
+		var err error
+
+		environment, err = plugins.New(&plugins.Args{
+			Language: language,
+			Version:  version,
+		}).Environment()
+		print.Error(err)
+	}
 
 	if len(environment) > 0 {
 		env := os.Environ()
@@ -53,12 +60,15 @@ func getVersion(language string) (version, dotPath string) {
 	plugin := plugins.New(&plugins.Args{
 		Language: language,
 	})
-	dotFiles := plugin.Dots()
 
-	version, dotPath, err := io.GetVersion(dotFiles)
+	version, dotPath, err := plugin.LocalVersion()
 	print.Error(err)
 
 	if version == "current" {
+		if plugin.Current() == versions.System {
+			version = versions.System
+		}
+
 		return
 	}
 
@@ -67,16 +77,83 @@ func getVersion(language string) (version, dotPath string) {
 	}
 
 	vers := plugin.List()
-	if len(vers) == 0 {
-		notInstalled(version, dotPath)
+	if len(vers) > 0 {
+		if found, err := versions.Latest(version, vers); err == nil {
+			return found, dotPath
+		}
 	}
 
-	found, err := versions.Latest(version, vers)
-	if err != nil {
-		notInstalled(version, dotPath)
+	// Remote version is used only if none of the installed ones match the mask
+	if variables.AutoInstall() {
+		return getRemoteVersion(plugin, version), dotPath
 	}
 
-	return found, dotPath
+	notInstalled(version, dotPath)
+
+	return
+}
+
+// Get newest remote version which matches the mask
+func getRemoteVersion(plugin *plugins.Plugin, mask string) string {
+	vers, err := plugin.RemoteVersions()
+	print.Error(err)
+
+	version, err := versions.Complete(mask, vers)
+	print.Error(err)
+
+	return version
+}
+
+// Should version be installed before the execution?
+func shouldInstall(language, version string) bool {
+	if version == "current" || version == versions.System {
+		return false
+	}
+
+	return variables.AutoInstall() && variables.IsInstalled(language, version) == false
+}
+
+// Install the version, output goes to stderr,
+// so it wouldn't mix with the output of the executed command
+func autoInstall(language, version string) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+		Version:  version,
+	})
+
+	print.FnInStyleln("language:", language)
+	print.InStyleln(" version:", version)
+
+	err := plugin.PreDownload()
+	print.Error(err)
+
+	response, err := plugin.Download()
+	print.Error(err)
+
+	// response == nil means we already downloaded that thing
+	if response != nil {
+		print.Download(response, version)
+		print.Error(response.Error)
+
+		err = plugin.Extract()
+		print.Error(err)
+	}
+
+	events.Setup(plugin)
+
+	err = plugin.BareInstall()
+	print.Error(err)
+
+	// Installed version should not be rolled back when executed command is interrupted
+	plugin.Release()
+
+	print.LastPrint()
 }
 
 func notInstalled(version, dotPath string) {
@@ -96,15 +173,29 @@ func notInstalled(version, dotPath string) {
 	print.Error(err)
 }
 
+// Get path to the binary of the language version
+func getBin(name, language, version string) string {
+	if version == versions.System {
+		binPath, err := plugins.SystemBin(name)
+		print.Error(err)
+
+		return binPath
+	}
+
+	return filepath.Join(variables.Path(language, version), "bin", name)
+}
+
 func main() {
 	_, name := path.Split(os.Args[0])
 
 	language := plugins.SearchBin(name)
 	version, dotPath := getVersion(language)
-	base := variables.Home()
 
-	pathPart := filepath.Join(base, language, version)
-	binPath := filepath.Join(pathPart, "bin", name)
+	if shouldInstall(language, version) {
+		autoInstall(language, version)
+	}
+
+	binPath := getBin(name, language, version)
 
 	if variables.IsDebug() {
 		fmt.Println("bin path: " + binPath)
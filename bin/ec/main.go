@@ -4,20 +4,48 @@ import (
 	"github.com/markelog/eclectica/cmd/commands"
 
 	// Commands
+	"github.com/markelog/eclectica/cmd/commands/alias"
+	"github.com/markelog/eclectica/cmd/commands/completion"
+	"github.com/markelog/eclectica/cmd/commands/config"
+	"github.com/markelog/eclectica/cmd/commands/du"
+	"github.com/markelog/eclectica/cmd/commands/env"
+	"github.com/markelog/eclectica/cmd/commands/hook-env"
+	"github.com/markelog/eclectica/cmd/commands/init"
 	"github.com/markelog/eclectica/cmd/commands/install"
+	"github.com/markelog/eclectica/cmd/commands/link"
 	"github.com/markelog/eclectica/cmd/commands/ls"
+	"github.com/markelog/eclectica/cmd/commands/migrate-home"
+	"github.com/markelog/eclectica/cmd/commands/outdated"
 	"github.com/markelog/eclectica/cmd/commands/path"
+	"github.com/markelog/eclectica/cmd/commands/prune"
+	"github.com/markelog/eclectica/cmd/commands/rc"
 	"github.com/markelog/eclectica/cmd/commands/remove-everything"
 	"github.com/markelog/eclectica/cmd/commands/rm"
+	"github.com/markelog/eclectica/cmd/commands/upgrade"
+	"github.com/markelog/eclectica/cmd/commands/use"
 	"github.com/markelog/eclectica/cmd/commands/version"
 )
 
 func main() {
 	commands.Register(install.Command)
 	commands.Register(rm.Command)
+	commands.Register(upgrade.Command)
+	commands.Register(outdated.Command)
+	commands.Register(prune.Command)
+	commands.Register(du.Command)
+	commands.Register(use.Command)
+	commands.Register(link.Command)
 	commands.Register(ls.Command)
+	commands.Register(alias.Command)
 	commands.Register(version.Command)
 	commands.Register(path.Command)
+	commands.Register(env.Command)
+	commands.Register(initialize.Command)
+	commands.Register(completion.Command)
+	commands.Register(hookEnv.Command)
+	commands.Register(config.Command)
+	commands.Register(migrateHome.Command)
+	commands.Register(rc.Command)
 	commands.Register(removeEverything.Command)
 
 	commands.Execute()
@@ -0,0 +1,136 @@
+// Package alias defines "alias" command i.e. names the language versions
+package alias
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Should alias be removed?
+var isRemove bool
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "alias <language> [<name>] [<version>]",
+	Short:             "name the language version",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: completeArgs,
+}
+
+// Command example
+var example = `
+  Name the version
+  $ ec alias node legacy 6.11.5
+
+  Use it as any other version
+  $ ec node@legacy
+  $ echo legacy > .node-version
+
+  Print version the alias points to
+  $ ec alias node legacy
+
+  List all aliases of the language
+  $ ec alias node
+
+  Remove the alias
+  $ ec alias --remove node legacy`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	if len(args) == 0 || len(args) > 3 {
+		print.Error(errors.New("Language, name and version of the alias should be provided"))
+	}
+
+	language := args[0]
+	if isSupported(language) == false {
+		cm := closestmatch.New(plugins.Plugins, []int{2})
+		print.ClosestLangWarning(language, cm.Closest(language))
+		return
+	}
+
+	if isRemove {
+		if len(args) != 2 {
+			print.Error(errors.New("Language and name of the alias should be provided"))
+		}
+
+		print.Error(aliases.Remove(language, args[1]))
+		return
+	}
+
+	switch len(args) {
+	case 1:
+		list(language)
+	case 2:
+		get(language, args[1])
+	case 3:
+		print.Error(aliases.Set(language, args[1], args[2]))
+	}
+}
+
+// list prints all aliases of the language
+func list(language string) {
+	defined, err := aliases.List(language)
+	print.Error(err)
+
+	names := []string{}
+	for name := range defined {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Println(name + " -> " + defined[name])
+	}
+}
+
+// get prints version the alias points to
+func get(language, name string) {
+	version := aliases.Get(language, name)
+	if version == "" {
+		print.Error(errors.New("Alias \"" + name + "\" for " + language + " is not defined"))
+	}
+
+	fmt.Println(version)
+}
+
+// completeArgs completes the language and then its aliases
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return complete.Languages(cmd, args, toComplete)
+	}
+
+	if len(args) == 1 {
+		return complete.Aliases(args[0], toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isSupported checks if there is a plugin for the language
+func isSupported(language string) bool {
+	for _, plugin := range plugins.Plugins {
+		if plugin == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+
+	flags.BoolVarP(&isRemove, "remove", "d", false, "remove the alias")
+}
@@ -5,6 +5,11 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
 )
 
 // Is action remote?
@@ -16,13 +21,24 @@ var isLocal bool
 // Reinstall global modules from previous version?
 var withModules bool
 
+// Format of the dot file for the local version
+var format string
+
+// Install everything defined by the project?
+var isProject bool
+
+// How many installations could be executed simultaneously
+var parallel int
+
 var use = "ec [<language>@<version>]"
 
 // Command config
 var Command = &cobra.Command{
-	Use:     use,
-	Example: example,
-	Hidden:  true,
+	Use:               use,
+	Example:           example,
+	Hidden:            true,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: complete.Versions,
 }
 
 // Register command to root command
@@ -37,10 +53,21 @@ func Execute() {
 	cmd, args, _ := Command.Find(args)
 	name := cmd.Name()
 
-	if name == "ec" && hasHelp(args) == false {
+	if name == "ec" && hasHelp(args) == false && isCompletion() == false {
 		augment()
 	}
 
+	// Malformed configuration should not be silently ignored,
+	// but it should be still possible to fix it with "ec config"
+	if _, err := variables.Config(); err != nil && isConfig(cmd) == false {
+		print.Error(err)
+	}
+
+	// Shared languages should be accessible for all users
+	if variables.IsSystem() {
+		io.SetPermissions(0755)
+	}
+
 	Command.Execute()
 }
 
@@ -53,7 +80,10 @@ func init() {
 	flags := Command.PersistentFlags()
 	flags.BoolVarP(&isRemote, "remote", "r", false, "ask for remote versions")
 	flags.BoolVarP(&isLocal, "local", "l", false, "install to the current folder only")
-	flags.BoolVarP(&withModules, "with-modules", "w", false, "reinstall global modules from the previous version (currently works only for node.js)")
+	flags.StringVarP(&format, "format", "", variables.LocalFormat(), "format of the dot file for the local version, \"auto\", \"language\" or \"tool-versions\"")
+	flags.BoolVarP(&withModules, "with-modules", "w", variables.WithModules(), "reinstall global modules from the previous version (currently works only for node.js)")
+	flags.BoolVarP(&isProject, "project", "p", false, "install all versions defined by the project")
+	flags.IntVarP(&parallel, "parallel", "j", 4, "how many versions could be installed simultaneously")
 }
 
 func isLanguageRelated(name string, args []string) bool {
@@ -76,6 +106,29 @@ func augment() {
 	os.Args = append(os.Args[:1], append([]string{"install"}, os.Args[1:]...)...)
 }
 
+// isCompletion checks if shell asks for completions,
+// these commands are added by cobra only on execution
+func isCompletion() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+
+	name := os.Args[1]
+
+	return name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd
+}
+
+// isConfig checks if command is "config" or one of its subcommands
+func isConfig(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd.Name() == "config" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func hasHelp(args []string) bool {
 	for _, elem := range args {
 		if elem == `--help` || elem == `-h` {
@@ -0,0 +1,61 @@
+// Package completion defines "completion" command i.e. outputs the shell completion script
+package completion
+
+import (
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/shell"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:       "completion <shell>",
+	Short:     "output shell completion script",
+	Example:   example,
+	ValidArgs: shell.Shells,
+	Run:       run,
+}
+
+// Command example
+var example = `
+  Load completion in bash
+  $ source <(ec completion bash)
+
+  Load completion in zsh
+  $ source <(ec completion zsh)
+
+  Load completion in fish
+  $ ec completion fish | source`
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	var err error
+
+	if len(args) != 1 {
+		print.Error(errors.New("Name of the shell should be provided"))
+	}
+
+	root := c.Root()
+
+	switch args[0] {
+	case "bash":
+		err = root.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		err = root.GenZshCompletion(os.Stdout)
+	case "fish":
+		err = root.GenFishCompletion(os.Stdout, true)
+	default:
+		err = errors.New(
+			"Shell \"" + args[0] + "\" is not supported, use one of \"" + strings.Join(shell.Shells, "\", \"") + "\"",
+		)
+	}
+
+	print.Error(err)
+
+	os.Exit(0)
+}
@@ -0,0 +1,149 @@
+// Package config defines "config" command i.e. reads and writes eclectica settings
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	eConfig "github.com/markelog/eclectica/config"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:     "config",
+	Short:   "get and set eclectica settings",
+	Long:    long,
+	Example: example,
+}
+
+var getCommand = &cobra.Command{
+	Use:   "get <key>",
+	Short: "print value of the setting",
+	Run:   get,
+}
+
+var setCommand = &cobra.Command{
+	Use:   "set <key> [<value>]",
+	Short: "set value of the setting, omit the value to reset it",
+	Run:   set,
+}
+
+var listCommand = &cobra.Command{
+	Use:   "list",
+	Short: "list all defined settings",
+	Run:   list,
+}
+
+// Command description
+var long = `Get and set settings of the "~/.eclectica/config.toml" file
+
+Settings are taken from the command flags, environment variables,
+configuration file and the defaults – in that order
+
+  auto-activate      switch versions on directory change in the "ec init"
+                     snippet (EC_AUTO_ACTIVATE, false)
+  auto-install       install missing version defined in the dot file when
+                     its binary is executed (EC_AUTO_INSTALL, false)
+  cache-ttl          how long list of the remote versions is cached (EC_CACHE_TTL, "10m")
+  connect-timeout    how long connection to the server is established (EC_CONNECT_TIMEOUT, "30s")
+  debug              print more info when executing commands (EC_DEBUG, false)
+  jobs               how many jobs are used to compile the language (EC_JOBS, number of CPUs)
+  local-format       format of the dot file for the local versions, "auto" updates
+                     the dot file which already defines the version, "language"
+                     or "tool-versions" (EC_LOCAL_FORMAT, "auto")
+  project-roots      folders with projects, separated like in the PATH, versions
+                     pinned by them are not pruned (EC_PROJECT_ROOTS)
+  proxy-place        folder where ec-proxy binary is located (EC_PROXY_PLACE)
+  read-timeout       how long data from the server is waited for (EC_READ_TIMEOUT, "1m")
+  restart-shell      start new shell when eclectica is not yet activated
+                     in the current one (EC_RESTART_SHELL, false)
+  retries            how many times failed request is retried (EC_RETRIES, 5)
+  system-root        folder where languages are installed for all users,
+                     like "/opt/eclectica" (EC_SYSTEM_ROOT)
+  with-modules       reinstall global modules from the previous version (EC_WITH_MODULES, false)
+  without-spinner    do not show the spinners (EC_WITHOUT_SPINNER, false)
+  mirrors.<name>.*   mirror for the language, with "url", "list", "username", "password"
+                     and "token" fields (EC_<NAME>_MIRROR, EC_<NAME>_MIRROR_LIST and etc)`
+
+// Command example
+var example = `
+  Cache list of the remote versions for an hour
+  $ ec config set cache-ttl 1h
+
+  Download node.js from the mirror
+  $ ec config set mirrors.node.url https://example.com/nodejs/dist
+
+  Reset the setting
+  $ ec config set jobs
+
+  Print all defined settings
+  $ ec config list`
+
+// Runners
+func get(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		print.Error(errors.New("Name of the setting should be provided"))
+	}
+
+	cfg, err := variables.Config()
+	print.Error(err)
+
+	value, err := cfg.Get(args[0])
+	print.Error(err)
+
+	fmt.Println(value)
+}
+
+func set(cmd *cobra.Command, args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		print.Error(errors.New("Name and value of the setting should be provided"))
+	}
+
+	value := ""
+	if len(args) == 2 {
+		value = args[1]
+	}
+
+	// Rewriting malformed file would lose the settings which are still there
+	cfg, err := variables.Config()
+	if err != nil {
+		print.Error(errors.New(err.Error() + ", fix or remove it before changing the settings"))
+	}
+
+	err = cfg.Set(args[0], value)
+	print.Error(err)
+
+	err = eConfig.Save(variables.ConfigPath(), cfg)
+	print.Error(err)
+}
+
+func list(cmd *cobra.Command, args []string) {
+	cfg, err := variables.Config()
+	print.Error(err)
+
+	rows := cfg.List()
+	if len(rows) == 0 {
+		os.Exit(0)
+	}
+
+	// Do not show the secrets
+	for _, row := range rows {
+		if strings.HasSuffix(row[0], ".password") || strings.HasSuffix(row[0], ".token") {
+			row[1] = "********"
+		}
+	}
+
+	print.Table([]string{"setting", "value"}, rows)
+}
+
+func init() {
+	Command.AddCommand(getCommand)
+	Command.AddCommand(setCommand)
+	Command.AddCommand(listCommand)
+}
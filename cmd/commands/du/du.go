@@ -0,0 +1,109 @@
+// Package du defines "du" command i.e. shows disk space taken by the versions,
+// their global packages, downloaded archives and build workspaces
+package du
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/usage"
+)
+
+// Output in JSON?
+var isJSON bool
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "du [<language>...]",
+	Short:             "show disk usage of the versions",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Languages,
+}
+
+// Command example
+var example = `
+  Show disk usage of all languages
+  $ ec du
+
+  Show disk usage of node.js versions
+  $ ec du node
+
+  Output disk usage in JSON
+  $ ec du --json`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	languages := plugins.Plugins
+
+	if len(args) > 0 {
+		cm := closestmatch.New(plugins.Plugins, []int{2})
+		languages = []string{}
+
+		for _, arg := range args {
+			language, _ := info.GetLanguage([]string{arg})
+
+			// Searching for closest plugin name
+			if language == "" {
+				possible := info.PossibleLanguage([]string{arg})
+				print.ClosestLangWarning(possible, cm.Closest(possible))
+				return
+			}
+
+			languages = append(languages, language)
+		}
+	}
+
+	report, err := usage.New(languages)
+	print.Error(err)
+
+	if isJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			print.Error(errors.New(err))
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(report.Entries) == 0 {
+		print.Error(errors.New("There is no installed versions"))
+	}
+
+	rows := [][]string{}
+	for _, entry := range report.Entries {
+		size := humanize.Bytes(uint64(entry.Size))
+
+		// Prefix of the linked version is not owned by eclectica
+		if entry.Kind == usage.Linked {
+			size = "-"
+		}
+
+		rows = append(rows, []string{
+			entry.Language,
+			entry.Version,
+			entry.Kind,
+			size,
+		})
+	}
+
+	print.Table([]string{"language", "version", "kind", "size"}, rows)
+	print.Green(humanize.Bytes(uint64(report.Total)) + " in total")
+	print.LastPrint()
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+	flags.BoolVarP(&isJSON, "json", "", false, "output in JSON")
+}
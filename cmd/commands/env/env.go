@@ -0,0 +1,154 @@
+// Package env defines "env" command i.e. outputs environment of the project versions,
+// so it could be used by the makefiles, services and editors
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/environment"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/shell"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "env [<language>@<version>...]",
+	Short:             "output environment of the versions used in the current folder",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Versions,
+}
+
+// Command example
+var example = `
+  Define environment in the current shell
+  $ eval "$(ec env)"
+
+  Use another version of node.js
+  $ eval "$(ec env node@8)"
+
+  Write environment file for the systemd unit or docker
+  $ ec env --shell dotenv > .env
+
+  Get environment for the IDE run configuration
+  $ ec env --shell json`
+
+// Formats are the possible output formats
+var formats = []string{"bash", "zsh", "fish", "json", "dotenv"}
+
+// Syntax of the output
+var format string
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	vers, err := environment.Current()
+	print.Error(err)
+
+	for _, arg := range args {
+		version, err := parse(arg)
+		print.Error(err)
+
+		vers = environment.Override(vers, version)
+	}
+
+	vars, err := environment.Variables(vers)
+	print.Error(err)
+
+	path := append(environment.Bins(vers), os.Getenv("PATH"))
+	vars["PATH"] = strings.Join(path, ":")
+
+	output, err := render(vars)
+	print.Error(err)
+
+	fmt.Print(output)
+
+	os.Exit(0)
+}
+
+// parse gets installed version from the "<language>@<version>" argument
+func parse(arg string) (environment.Version, error) {
+	parts := strings.SplitN(arg, "@", 2)
+
+	if len(parts) != 2 || parts[1] == "" {
+		return environment.Version{}, errors.New("Version should be defined like \"node@8.1.0\"")
+	}
+
+	for _, language := range plugins.Plugins {
+		if language == parts[0] {
+			return environment.Resolve(parts[0], parts[1])
+		}
+	}
+
+	return environment.Version{}, errors.New("Eclectica does not support \"" + parts[0] + "\"")
+}
+
+// render gets variables in the requested format
+func render(vars map[string]string) (result string, err error) {
+	keys := environment.Keys(vars)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(vars, "", "  ")
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		return string(data) + "\n", nil
+	case "dotenv":
+		for _, key := range keys {
+			result += key + "=" + dotenv(vars[key]) + "\n"
+		}
+	case "bash", "zsh", "fish":
+		for _, key := range keys {
+			result += shell.Export(format, key, vars[key])
+		}
+	default:
+		return "", errors.New(
+			"Format should be one of \"" + strings.Join(formats, "\", \"") + "\"",
+		)
+	}
+
+	return
+}
+
+// dotenv quotes the value only if needed, since not every consumer removes the quotes
+func dotenv(value string) string {
+	if strings.ContainsAny(value, " \t\n\"'#$\\") == false {
+		return value
+	}
+
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+
+	return `"` + value + `"`
+}
+
+// defaultFormat gets syntax of the current shell, if it is supported
+func defaultFormat() string {
+	name := shell.Name()
+
+	for _, elem := range shell.Shells {
+		if elem == name {
+			return name
+		}
+	}
+
+	return "bash"
+}
+
+func init() {
+	flags := Command.PersistentFlags()
+	flags.StringVarP(
+		&format, "shell", "s", defaultFormat(),
+		"output format, one of \""+strings.Join(formats, "\", \"")+"\"",
+	)
+}
@@ -0,0 +1,112 @@
+// Package hookEnv defines "hook-env" command i.e. outputs environment of the versions
+// defined for the current directory, it is used by the "ec init" snippet
+package hookEnv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/environment"
+	"github.com/markelog/eclectica/shell"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:    "hook-env",
+	Short:  "output environment for the versions of the current directory",
+	Run:    run,
+	Hidden: true,
+}
+
+// Syntax of which shell should be used
+var shellName string
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	var (
+		previous = environment.Decode(os.Getenv(shell.HookEnv))
+		bins     = filepath.SplitList(os.Getenv(shell.HookPath))
+	)
+
+	// Environment of the versions is composed as if the hook was never executed,
+	// since plugins might take into account what was defined by the user
+	previous.Restore()
+
+	vers := environment.Local()
+	added := environment.Bins(vers)
+
+	// Errors are ignored since hook is executed before every prompt
+	vars, err := environment.Variables(vers)
+	if err != nil {
+		vars = map[string]string{}
+	}
+
+	snapshot := environment.Save(environment.Keys(vars))
+
+	for _, key := range previous.Keys() {
+		if _, ok := vars[key]; ok {
+			continue
+		}
+
+		if previous[key] == nil {
+			fmt.Print(shell.Unset(shellName, key))
+		} else {
+			fmt.Print(shell.Export(shellName, key, *previous[key]))
+		}
+	}
+
+	for _, key := range environment.Keys(vars) {
+		fmt.Print(shell.Export(shellName, key, vars[key]))
+	}
+
+	path := append(added, without(filepath.SplitList(os.Getenv("PATH")), bins)...)
+	fmt.Print(shell.Export(shellName, "PATH", strings.Join(path, ":")))
+
+	if len(added) == 0 {
+		fmt.Print(shell.Unset(shellName, shell.HookPath))
+	} else {
+		fmt.Print(shell.Export(shellName, shell.HookPath, strings.Join(added, ":")))
+	}
+
+	if len(snapshot) == 0 {
+		fmt.Print(shell.Unset(shellName, shell.HookEnv))
+	} else {
+		encoded, err := snapshot.Encode()
+		print.Error(err)
+
+		fmt.Print(shell.Export(shellName, shell.HookEnv, encoded))
+	}
+
+	os.Exit(0)
+}
+
+// without gets elements of the list except the excluded ones
+func without(list, excluded []string) (result []string) {
+	for _, elem := range list {
+		if contains(excluded, elem) == false {
+			result = append(result, elem)
+		}
+	}
+
+	return
+}
+
+func contains(list []string, value string) bool {
+	for _, elem := range list {
+		if elem == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func init() {
+	flags := Command.PersistentFlags()
+	flags.StringVarP(&shellName, "shell", "s", "bash", "output in the syntax of the shell")
+}
@@ -0,0 +1,60 @@
+// Package initialize defines "init" command i.e.
+// outputs snippet which activates eclectica in the shell
+package initialize
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/shell"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:     "init [<shell>]",
+	Short:   "output snippet which activates eclectica in the shell",
+	Example: example,
+	Run:     run,
+}
+
+// Command example
+var example = `
+  Activate eclectica in bash or zsh
+  $ eval "$(ec init bash)"
+
+  Activate eclectica in fish
+  $ ec init fish | source
+
+  Also switch versions when directory changes
+  $ eval "$(ec init zsh --auto-activate)"`
+
+// Switch versions on the directory change
+var isAuto bool
+
+// Runner
+func run(c *cobra.Command, args []string) {
+	name := shell.Name()
+
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	snippet, err := shell.Init(name, isAuto)
+	print.Error(err)
+
+	fmt.Print(snippet)
+
+	os.Exit(0)
+}
+
+func init() {
+	flags := Command.PersistentFlags()
+	flags.BoolVarP(
+		&isAuto, "auto-activate", "a", variables.AutoActivate(),
+		"switch versions when directory changes",
+	)
+}
@@ -0,0 +1,15 @@
+package install
+
+// Internals which are replaced or checked by the tests
+var (
+	InstallTargets = installTargets
+
+	Parallel = &parallel
+	Process  = &process
+	Apply    = &apply
+)
+
+// Status gets the result of the target installation
+func (target *Target) Status() string {
+	return target.status
+}
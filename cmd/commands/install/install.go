@@ -7,9 +7,13 @@ import (
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/events"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
 	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
 )
 
@@ -22,19 +26,23 @@ var isLocal bool
 // Is action local?
 var withModules bool
 
+// Format of the dot file for the local version
+var format string
+
+// Install everything defined by the project?
+var isProject bool
+
 // Command represents the ls command
 var Command = &cobra.Command{
-	Use:   "install [<language>@<version>]",
-	Short: "install language",
-	Run:   run,
+	Use:               "install [<language>@<version>...]",
+	Short:             "install language",
+	Run:               run,
+	ValidArgsFunction: complete.Versions,
 
 	// Rather use "install [<language>@<version>]"
 	Hidden: true,
 }
 
-// Event type handler
-type handleFn func(args ...string)
-
 // getVersion gets version of the language and its correlated version
 func getVersion(language, version string) string {
 	remoteList, err := info.FullListRemote(language)
@@ -52,7 +60,7 @@ func conditionalInstall(plugin *plugins.Plugin) {
 		err error
 	)
 
-	SetupEvents(plugin)
+	events.Setup(plugin)
 
 	if isLocal {
 		err = plugin.LocalInstall()
@@ -70,6 +78,7 @@ func install(language, version string) {
 		Language:    language,
 		Version:     version,
 		WithModules: withModules,
+		Format:      format,
 	})
 
 	err := plugin.PreDownload()
@@ -81,6 +90,7 @@ func install(language, version string) {
 	// response == nil means we already downloaded that thing
 	if response != nil {
 		print.Download(response, plugin.Version)
+		print.Error(response.Error)
 
 		err = plugin.Extract()
 		print.Error(err)
@@ -101,6 +111,23 @@ func run(cmd *cobra.Command, args []string) {
 
 	// We don't use cobra here, since we support `ec <language>@<version>` syntax
 
+	// In case of `ec <language>@<alias>`
+	if hasVersion {
+		version = aliases.Resolve(language, version)
+	}
+
+	// In case of `ec install --project`
+	if isProject {
+		installProject()
+		return
+	}
+
+	// In case of `ec <language>@<version> <language>@<version> ...`
+	if len(args) > 1 && hasVersion {
+		installMultiple(args)
+		return
+	}
+
 	// Searching for closest plugin name
 	if len(args) > 0 && hasLanguage == false {
 		possible := info.PossibleLanguage(args)
@@ -178,5 +205,8 @@ func init() {
 	flags := Command.PersistentFlags()
 	flags.BoolVarP(&isRemote, "remote", "r", false, "get remote versions")
 	flags.BoolVarP(&isLocal, "local", "l", false, "install as local version")
-	flags.BoolVarP(&withModules, "with-modules", "w", false, "reinstall global modules from the previous version (currently works only for node.js)")
+	flags.StringVarP(&format, "format", "", variables.LocalFormat(), "format of the dot file for the local version, \"auto\", \"language\" or \"tool-versions\"")
+	flags.BoolVarP(&withModules, "with-modules", "w", variables.WithModules(), "reinstall global modules from the previous version (currently works only for node.js)")
+	flags.BoolVarP(&isProject, "project", "p", false, "install all versions defined by the project")
+	flags.IntVarP(&parallel, "parallel", "j", 4, "how many versions could be installed simultaneously")
 }
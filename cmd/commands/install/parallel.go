@@ -0,0 +1,328 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/cmd/print/progress"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/shell"
+	"github.com/markelog/eclectica/versions"
+)
+
+// How many installations could be executed simultaneously
+var parallel int
+
+var (
+
+	// process installs the target, replaced with the stub in the tests
+	process = processTarget
+
+	// apply makes installed target the current version, replaced with the stub in the tests
+	apply = func(target *Target) error {
+		return target.plugin.Activate(isLocal)
+	}
+)
+
+// Target is the language version which should be installed
+type Target struct {
+	Language string
+	Version  string
+
+	line   *progress.Line
+	plugin *plugins.Plugin
+	status string
+	err    error
+}
+
+// Events which are shown on the progress line
+var notes = map[string]string{
+	"configure":       "configure",
+	"prepare":         "prepare",
+	"install":         "install",
+	"postinstall":     "postinstall",
+	"reapply modules": "reapply global modules",
+}
+
+// Install all passed targets at once,
+// like `ec node@8 go@1.9 python@3.6`
+func installMultiple(args []string) {
+	cm := closestmatch.New(plugins.Plugins, []int{2})
+	targets := []*Target{}
+
+	for _, arg := range args {
+		language, version := info.GetLanguage([]string{arg})
+
+		// Searching for closest plugin name
+		if language == "" {
+			possible := info.PossibleLanguage([]string{arg})
+			print.ClosestLangWarning(possible, cm.Closest(possible))
+			return
+		}
+
+		if version == "" {
+			print.Error(errors.New("version for \"" + language + "\" was not defined"))
+		}
+
+		targets = append(targets, &Target{
+			Language: language,
+			Version:  version,
+		})
+	}
+
+	init := shell.New(plugins.Plugins)
+	init.Check()
+
+	err := init.Initiate()
+	print.Error(err)
+
+	failed := installTargets(targets, true)
+	printSummary(targets)
+
+	if failed {
+		os.Exit(1)
+	}
+
+	// Start new shell from eclectica if needed
+	// note: should be the last action
+	init.Start()
+}
+
+// installTargets installs targets simultaneously, but not more then `parallel`
+// at the same time. Versions of the same language are installed one after another,
+// since their builds might share the same workspace.
+// If activate is true, installed versions will become current ones, that is done
+// one after another once everything is installed, since dot files might be shared
+func installTargets(targets []*Target, activate bool) (failed bool) {
+	var (
+		groups    = map[string][]*Target{}
+		order     = []string{}
+		width     = 0
+		jobs      = make(chan []*Target)
+		waitGroup = &sync.WaitGroup{}
+		bar       = progress.New()
+	)
+
+	for _, target := range targets {
+		if len(target.Language) > width {
+			width = len(target.Language)
+		}
+	}
+
+	for _, target := range targets {
+		if _, ok := groups[target.Language]; ok == false {
+			order = append(order, target.Language)
+		}
+
+		groups[target.Language] = append(groups[target.Language], target)
+
+		header := target.Language + strings.Repeat(" ", width-len(target.Language))
+		target.line = bar.Add(header, target.Version)
+		target.line.Set("waiting", "")
+	}
+
+	workers := parallel
+	if workers < 1 {
+		workers = 1
+	}
+
+	fmt.Println()
+	bar.Start()
+
+	for i := 0; i < workers; i++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for group := range jobs {
+				for _, target := range group {
+					installTarget(target, activate)
+				}
+			}
+		}()
+	}
+
+	for _, language := range order {
+		jobs <- groups[language]
+	}
+
+	close(jobs)
+	waitGroup.Wait()
+
+	if activate {
+		for _, target := range targets {
+			activateTarget(target)
+		}
+	}
+
+	bar.Stop()
+
+	for _, target := range targets {
+		if target.err != nil {
+			failed = true
+		}
+	}
+
+	return
+}
+
+// installTarget downloads and installs one target, updating its progress line,
+// errors are stored in the target so they would not affect other ones
+func installTarget(target *Target, activate bool) {
+	status, err := process(target)
+	if err != nil {
+		fail(target, err)
+		return
+	}
+
+	target.status = status
+
+	// Line is finished when target is activated
+	if activate {
+		target.line.Set("waiting", "")
+		return
+	}
+
+	target.line.Succeed(status)
+}
+
+// activateTarget makes installed target the current version
+func activateTarget(target *Target) {
+	if target.err != nil {
+		return
+	}
+
+	err := apply(target)
+	if err != nil {
+		fail(target, err)
+		return
+	}
+
+	if target.status == "already installed" {
+		target.status = "switched"
+	}
+
+	target.line.Succeed(target.status)
+}
+
+// fail marks the target as failed
+func fail(target *Target, err error) {
+	target.err = err
+	target.status = "failed: " + err.Error()
+	target.line.Fail(err)
+}
+
+func processTarget(target *Target) (status string, err error) {
+	line := target.line
+
+	// In case of the alias, like `legacy`
+	if version := aliases.Resolve(target.Language, target.Version); version != target.Version {
+		target.Version = version
+		line.SetItem(version)
+	}
+
+	// In case of the mask, like `6` or `latest`
+	if versions.IsPartial(target.Version) {
+		line.Set("resolve", "")
+
+		remoteList, errList := plugins.New(&plugins.Args{
+			Language: target.Language,
+		}).RemoteVersions()
+		if errList != nil {
+			return "", errList
+		}
+
+		version, errComplete := versions.Complete(target.Version, remoteList)
+		if errComplete != nil {
+			return "", errComplete
+		}
+
+		target.Version = version
+		line.SetItem(version)
+	}
+
+	plugin := plugins.New(&plugins.Args{
+		Language:    target.Language,
+		Version:     target.Version,
+		WithModules: withModules,
+		Format:      format,
+	})
+
+	target.plugin = plugin
+
+	// Interruption should only roll back targets which are still in progress
+	defer plugin.Release()
+
+	for event, note := range notes {
+		note := note
+
+		plugin.Events().On(event, func(args ...string) {
+			var message string
+
+			if len(args) > 0 {
+				message = args[0]
+			}
+
+			line.Set(note, message)
+		})
+	}
+
+	if plugin.IsInstalled() {
+		return "already installed", nil
+	}
+
+	err = plugin.PreDownload()
+	if err != nil {
+		return
+	}
+
+	response, err := plugin.Download()
+	if err != nil {
+		return
+	}
+
+	// response == nil means we already downloaded that thing
+	if response != nil {
+		line.Download(response)
+
+		err = response.Wait()
+		if err != nil {
+			return
+		}
+
+		line.Set("extract", "")
+
+		err = plugin.Extract()
+		if err != nil {
+			return
+		}
+	}
+
+	err = plugin.BareInstall()
+	if err != nil {
+		return
+	}
+
+	return "installed", nil
+}
+
+// printSummary prints the table with results for every target
+func printSummary(targets []*Target) {
+	rows := [][]string{}
+
+	for _, target := range targets {
+		rows = append(rows, []string{target.Language, target.Version, target.status})
+	}
+
+	print.Table([]string{"language", "version", "status"}, rows)
+	print.LastPrint()
+}
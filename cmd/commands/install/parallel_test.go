@@ -0,0 +1,171 @@
+package install_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/cmd/commands/install"
+)
+
+var _ = Describe("parallel", func() {
+	var (
+		mutex     *sync.Mutex
+		running   map[string]int
+		installed []string
+		activated []string
+
+		parallel = *Parallel
+		process  = *Process
+		apply    = *Apply
+	)
+
+	targets := func(args ...string) (result []*Target) {
+		for i := 0; i < len(args); i += 2 {
+			result = append(result, &Target{Language: args[i], Version: args[i+1]})
+		}
+
+		return
+	}
+
+	BeforeEach(func() {
+		mutex = &sync.Mutex{}
+		running = map[string]int{}
+		installed = []string{}
+		activated = []string{}
+
+		os.Setenv("EC_WITHOUT_SPINNER", "true")
+
+		*Apply = func(target *Target) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			activated = append(activated, target.Language+"@"+target.Version)
+
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_WITHOUT_SPINNER")
+
+		*Parallel = parallel
+		*Process = process
+		*Apply = apply
+	})
+
+	// stub installs the target pretending it takes some time,
+	// "fail" version of any language could not be installed
+	stub := func(check func(running map[string]int)) func(*Target) (string, error) {
+		return func(target *Target) (string, error) {
+			mutex.Lock()
+			running[target.Language]++
+			check(running)
+			mutex.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			running[target.Language]--
+
+			if target.Version == "fail" {
+				return "", errors.New("Something went wrong")
+			}
+
+			installed = append(installed, target.Language+"@"+target.Version)
+
+			return "installed", nil
+		}
+	}
+
+	It("should install and activate every target", func() {
+		*Parallel = 2
+		*Process = stub(func(map[string]int) {})
+
+		list := targets("node", "8.9.4", "go", "1.9.2", "python", "3.6.4")
+		failed := InstallTargets(list, true)
+
+		Expect(failed).To(Equal(false))
+		Expect(installed).To(ConsistOf("node@8.9.4", "go@1.9.2", "python@3.6.4"))
+		Expect(activated).To(Equal([]string{"node@8.9.4", "go@1.9.2", "python@3.6.4"}))
+
+		for _, target := range list {
+			Expect(target.Status()).To(Equal("installed"))
+		}
+	})
+
+	It("should not install more targets at once than it is allowed", func() {
+		most := 0
+
+		*Parallel = 2
+		*Process = stub(func(running map[string]int) {
+			sum := 0
+			for _, count := range running {
+				sum += count
+			}
+
+			if sum > most {
+				most = sum
+			}
+		})
+
+		failed := InstallTargets(targets(
+			"node", "8.9.4", "go", "1.9.2", "python", "3.6.4", "rust", "1.22.1", "elm", "0.18.0",
+		), false)
+
+		Expect(failed).To(Equal(false))
+		Expect(installed).To(HaveLen(5))
+		Expect(most).To(BeNumerically("<=", 2))
+	})
+
+	It("should install versions of the same language one after another", func() {
+		most := 0
+
+		*Parallel = 4
+		*Process = stub(func(running map[string]int) {
+			if running["node"] > most {
+				most = running["node"]
+			}
+		})
+
+		failed := InstallTargets(targets("node", "6.11.5", "go", "1.9.2", "node", "8.9.4"), false)
+
+		Expect(failed).To(Equal(false))
+		Expect(most).To(Equal(1))
+		Expect(installed).To(HaveLen(3))
+		Expect(installed).To(ContainElement("go@1.9.2"))
+
+		nodes := []string{}
+		for _, target := range installed {
+			if target != "go@1.9.2" {
+				nodes = append(nodes, target)
+			}
+		}
+
+		Expect(nodes).To(Equal([]string{"node@6.11.5", "node@8.9.4"}))
+	})
+
+	It("should install other targets if one of them fails", func() {
+		*Parallel = 2
+		*Process = stub(func(map[string]int) {})
+
+		list := targets("node", "8.9.4", "go", "fail", "python", "3.6.4")
+		failed := InstallTargets(list, true)
+
+		// Which makes command exit with 1 code
+		Expect(failed).To(Equal(true))
+
+		Expect(installed).To(ConsistOf("node@8.9.4", "python@3.6.4"))
+		Expect(activated).To(Equal([]string{"node@8.9.4", "python@3.6.4"}))
+
+		Expect(list[0].Status()).To(Equal("installed"))
+		Expect(list[1].Status()).To(Equal("failed: Something went wrong"))
+		Expect(list[2].Status()).To(Equal("installed"))
+	})
+})
@@ -0,0 +1,49 @@
+package install
+
+import (
+	"os"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/project"
+	"github.com/markelog/eclectica/shell"
+)
+
+// Install everything defined by the project
+func installProject() {
+	defined, err := project.Versions()
+	print.Error(err)
+
+	if len(defined) == 0 {
+		print.Error(errors.New("There is no versions defined for this project"))
+	}
+
+	targets := []*Target{}
+	for _, version := range defined {
+		targets = append(targets, &Target{
+			Language: version.Language,
+			Version:  version.Version,
+		})
+	}
+
+	init := shell.New(plugins.Plugins)
+	init.Check()
+
+	err = init.Initiate()
+	print.Error(err)
+
+	// Versions are already defined by the project dot files,
+	// so there is no need to switch to them
+	failed := installTargets(targets, false)
+	printSummary(targets)
+
+	if failed {
+		os.Exit(1)
+	}
+
+	// Start new shell from eclectica if needed
+	// note: should be the last action
+	init.Start()
+}
@@ -0,0 +1,83 @@
+// Package link defines "link" command i.e. registers externally built versions
+package link
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "link <language>@<version> <prefix>",
+	Short:             "register externally built version",
+	Long:              long,
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Languages,
+}
+
+// Command description
+var long = `Register version built outside of eclectica, like the patched one
+or the one installed by the distro package, it is used as any other installed version.
+
+Prefix is only linked, "ec rm" removes the link, but never the prefix itself`
+
+// Command example
+var example = `
+  Register patched node.js
+  $ ec link node@8.9.4-custom ~/builds/node
+
+  Register python installed by the package
+  $ ec link python@3.6.4 /opt/python3.6
+
+  Switch to it
+  $ ec use node@8.9.4-custom`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		print.Error(errors.New("Version and prefix should be defined like \"ec link node@8.9.4-custom /opt/node\""))
+	}
+
+	var (
+		language, version = info.GetLanguage(args[:1])
+		cm                = closestmatch.New(plugins.Plugins, []int{2})
+	)
+
+	// Searching for closest plugin name
+	if info.HasLanguage(args[:1]) == false {
+		possible := info.PossibleLanguage(args[:1])
+		print.ClosestLangWarning(possible, cm.Closest(possible))
+		return
+	}
+
+	if version == "" {
+		print.Error(errors.New("Version should be defined like \"" + language + "@8.9.4-custom\""))
+	}
+
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+		Version:  version,
+	})
+
+	err := plugin.Register(args[1])
+	print.Error(err)
+
+	// Proxies are needed if this is the first version of the language
+	err = plugin.Proxy()
+	if err != nil {
+		plugin.Remove()
+		print.Error(err)
+	}
+
+	print.FnInStyleln("language:", language)
+	print.InStyleln(" version:", version)
+	print.Green("Linked, use \"ec use " + language + "@" + version + "\" to switch to it")
+	print.LastPrint()
+}
@@ -3,14 +3,16 @@ package ls
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/complete"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
-	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/list"
 	"github.com/markelog/eclectica/plugins"
 )
@@ -20,11 +22,12 @@ var isRemote bool
 
 // Command represents the ls command
 var Command = &cobra.Command{
-	Use:     "ls",
-	Aliases: []string{"list"},
-	Short:   "list installed language versions",
-	Example: example,
-	Run:     run,
+	Use:               "ls",
+	Aliases:           []string{"list"},
+	Short:             "list installed language versions",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Languages,
 }
 
 // Command example
@@ -61,16 +64,23 @@ func run(cmd *cobra.Command, args []string) {
 	}
 }
 
-// List versions
-func listVersions(versions []string, current string) {
+// List versions, with the aliases pointing to them
+func listVersions(language string, versions []string, current string) {
+	names := aliases.Names(language)
+
 	fmt.Println()
 	for i, version := range versions {
+		label := version
+		if len(names[version]) > 0 {
+			label += " (" + strings.Join(names[version], ", ") + ")"
+		}
+
 		if current == version {
-			print.CurrentVersion(version)
+			print.CurrentVersion(label)
 			continue
 		}
 
-		print.Version(version)
+		print.Version(label)
 
 		if i == 9 {
 			print.Version("...", "white")
@@ -94,7 +104,7 @@ func listLocalVersions(language string) {
 		print.Error(err)
 	}
 
-	current, _, err := io.GetVersion(plugin.Dots())
+	current, _, err := plugin.LocalVersion()
 	print.Error(err)
 
 	// In case we could find `.<language>-version` file i.e. there is no local version
@@ -102,7 +112,7 @@ func listLocalVersions(language string) {
 		current = plugin.Current()
 	}
 
-	listVersions(versions, current)
+	listVersions(language, versions, current)
 }
 
 // Ask for language and list local versions
@@ -123,7 +133,7 @@ func listRemoteVersions(language string) {
 		Language: language,
 	}).Current()
 
-	listVersions(versions, current)
+	listVersions(language, versions, current)
 }
 
 // Ask for language and list remote versions
@@ -0,0 +1,103 @@
+// Package migrateHome defines "migrate-home" command i.e.
+// move eclectica home with all the installed languages to another place
+package migrateHome
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/rc"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:     "migrate-home <destination>",
+	Short:   "move eclectica home with all the installed languages to another place",
+	Example: example,
+	Run:     run,
+}
+
+// Command example
+var example = `
+  Move eclectica home to the shared volume
+  $ ec migrate-home /data/eclectica
+
+  Move it back to the default place
+  $ ec migrate-home ~/.eclectica`
+
+func run(c *cobra.Command, args []string) {
+	if len(args) != 1 {
+		print.Error(errors.New("Destination should be provided"))
+	}
+
+	var (
+		from    = variables.Base()
+		to, err = filepath.Abs(args[0])
+	)
+	print.Error(err)
+
+	err = move(from, to)
+	print.Error(err)
+
+	// "current" versions are absolute symlinks
+	err = io.RewriteSymlinks(to, from, to)
+	print.Error(err)
+
+	// Relocated home should be defined in the rc file
+	os.Setenv("EC_HOME", to)
+
+	err = rc.New().Add()
+	print.Error(err)
+
+	print.Green("Eclectica home moved to \"" + to + "\", restart the shell to apply the changes")
+}
+
+// move moves the folder, even to another device
+func move(from, to string) error {
+	if _, err := os.Stat(from); err != nil {
+		return errors.New("There is nothing to migrate in \"" + from + "\"")
+	}
+
+	if from == to {
+		return errors.New("Eclectica home is already located in \"" + to + "\"")
+	}
+
+	if strings.HasPrefix(to, from+"/") {
+		return errors.New("Eclectica home cannot be moved inside of itself")
+	}
+
+	if _, err := os.Stat(to); err == nil {
+		return errors.New("\"" + to + "\" already exists")
+	}
+
+	_, err := io.CreateDir(filepath.Dir(to))
+	if err != nil {
+		return err
+	}
+
+	if os.Rename(from, to) == nil {
+		return nil
+	}
+
+	// Rename doesn't work across devices
+	output, err := exec.Command("cp", "-a", from, to).CombinedOutput()
+	if err != nil {
+		os.RemoveAll(to)
+		return errors.New(strings.TrimSpace(string(output)))
+	}
+
+	err = os.RemoveAll(from)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
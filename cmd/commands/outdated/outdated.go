@@ -0,0 +1,256 @@
+// Package outdated defines "outdated" command i.e. reports versions with newer releases
+// or past their end-of-life, either installed or pinned by the projects
+package outdated
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	eOutdated "github.com/markelog/eclectica/outdated"
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Folder with the projects which should be scanned
+var scan string
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "outdated [<language>...]",
+	Short:             "report outdated and end-of-life versions",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Languages,
+}
+
+// Command example
+var example = `
+  Report installed versions of all languages
+  $ ec outdated
+
+  Report installed node.js versions
+  $ ec outdated node
+
+  Report projects which pin outdated versions
+  $ ec outdated --scan ~/projects`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	languages := plugins.Plugins
+
+	if len(args) > 0 {
+		cm := closestmatch.New(plugins.Plugins, []int{2})
+		languages = []string{}
+
+		for _, arg := range args {
+			language, _ := info.GetLanguage([]string{arg})
+
+			// Searching for closest plugin name
+			if language == "" {
+				possible := info.PossibleLanguage([]string{arg})
+				print.ClosestLangWarning(possible, cm.Closest(possible))
+				return
+			}
+
+			languages = append(languages, language)
+		}
+	}
+
+	if scan != "" {
+		projects(scan, languages)
+	} else {
+		installed(languages)
+	}
+
+	print.LastPrint()
+}
+
+// installed reports installed versions of the languages
+func installed(languages []string) {
+	var (
+		rows = [][]string{}
+		now  = time.Now()
+	)
+
+	for _, language := range languages {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		vers := plugin.List()
+		if len(vers) == 0 {
+			continue
+		}
+
+		remote, dates := fetch(plugin, language)
+		report := eOutdated.New(language, plugin.Current(), vers, remote, dates)
+
+		for _, version := range report.Versions {
+			notes := describe(version, now)
+
+			if version.Version == report.Current {
+				notes = append([]string{"current"}, notes...)
+			}
+
+			rows = append(rows, []string{
+				language,
+				version.Version,
+				patch(version),
+				newest(report),
+				strings.Join(notes, ", "),
+			})
+		}
+	}
+
+	if len(rows) == 0 {
+		print.Error(errors.New("There is no installed versions"))
+	}
+
+	print.Table([]string{"language", "installed", "patch", "newest", "notes"}, rows)
+}
+
+// projects reports versions pinned by the projects in the folder
+func projects(root string, languages []string) {
+	var (
+		rows  = [][]string{}
+		now   = time.Now()
+		cache = map[string]*metadata{}
+	)
+
+	pins, err := eOutdated.Scan(root)
+	print.Error(err)
+
+	for _, pin := range pins {
+		if pin.Error != nil {
+			print.Warning("Skipped \""+pin.Path+"\": "+pin.Error.Error(), "")
+			continue
+		}
+
+		if isOneOf(pin.Language, languages) == false {
+			continue
+		}
+
+		if _, ok := cache[pin.Language]; ok == false {
+			plugin := plugins.New(&plugins.Args{
+				Language: pin.Language,
+			})
+
+			remote, dates := fetch(plugin, pin.Language)
+			cache[pin.Language] = &metadata{remote, dates}
+		}
+
+		data := cache[pin.Language]
+		version := eOutdated.Check(pin.Version, data.remote, data.dates)
+
+		if version.IsOutdated(now) == false {
+			continue
+		}
+
+		rows = append(rows, []string{
+			relative(root, filepath.Dir(pin.Path)),
+			pin.Language,
+			pin.Version,
+			patch(version),
+			strings.Join(describe(version, now), ", "),
+		})
+	}
+
+	if len(rows) == 0 {
+		print.Green("None of the projects pin outdated versions")
+		return
+	}
+
+	print.Table([]string{"project", "language", "pinned", "patch", "notes"}, rows)
+}
+
+// metadata is the remote versions and end-of-life dates of the language
+type metadata struct {
+	remote []string
+	dates  eOutdated.Dates
+}
+
+// fetch gets remote versions and end-of-life dates of the language, neither is essential,
+// so the report is shown without them if they are not available
+func fetch(plugin *plugins.Plugin, language string) ([]string, eOutdated.Dates) {
+	remote, err := plugin.RemoteVersions()
+	if err != nil {
+		remote = nil
+		print.Warning("Remote versions of "+language+" are not available: "+err.Error(), "")
+	}
+
+	dates, err := eOutdated.EOL(language)
+	if err != nil {
+		dates = eOutdated.Dates{}
+	}
+
+	return remote, dates
+}
+
+// describe gets notes about the version
+func describe(version *eOutdated.Version, now time.Time) (notes []string) {
+	if version.IsEOL(now) {
+		notes = append(notes, "end-of-life since "+version.EOL.Format("2006-01-02"))
+	}
+
+	return
+}
+
+// patch gets the newest release of the version line for the output
+func patch(version *eOutdated.Version) string {
+	if version.HasPatch() {
+		return version.Patch
+	}
+
+	if version.Patch == "" {
+		return "-"
+	}
+
+	return "up to date"
+}
+
+// newest gets the newest release of the language for the output
+func newest(report *eOutdated.Report) string {
+	if report.Newest == "" {
+		return "-"
+	}
+
+	return report.Newest
+}
+
+// relative gets path relative to the root, if possible
+func relative(root, path string) string {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(abs, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, elem := range names {
+		if elem == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+	flags.StringVarP(&scan, "scan", "s", "", "report projects in the folder which pin outdated versions")
+}
@@ -20,16 +20,35 @@ var Command = &cobra.Command{
 	Hidden: true,
 }
 
+// Syntax of which shell should be used
+var shellName string
+
 // Updates the path environment variable
 func run(c *cobra.Command, args []string) {
 	path := os.Getenv("PATH")
 	addition := shell.Compose(plugins.Plugins)
 
-	if strings.Contains(path, addition) {
-		fmt.Print(path)
-	} else {
-		fmt.Print(addition + ":" + path)
+	if strings.Contains(path, addition) == false {
+		path = addition + ":" + path
+	}
+
+	// Fish splits output of the command substitution by the lines
+	if shellName == "fish" {
+		for _, elem := range strings.Split(path, ":") {
+			if elem != "" {
+				fmt.Println(elem)
+			}
+		}
+
+		os.Exit(0)
 	}
 
+	fmt.Print(path)
+
 	os.Exit(0)
 }
+
+func init() {
+	flags := Command.PersistentFlags()
+	flags.StringVarP(&shellName, "shell", "s", "", "output in the syntax of the shell (only \"fish\" differs)")
+}
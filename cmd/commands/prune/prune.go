@@ -0,0 +1,106 @@
+// Package prune defines "prune" command i.e. removes unused versions and leftovers of the installations
+package prune
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	ePrune "github.com/markelog/eclectica/prune"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Only show what would be removed?
+var isDryRun bool
+
+// How many newest versions are kept for every major
+var keep int
+
+// Keep versions the aliases point to?
+var keepAliases bool
+
+// Folders with projects which versions are kept
+var roots []string
+
+// Prune in the system mode?
+var isForce bool
+
+// Command config
+var Command = &cobra.Command{
+	Use:     "prune",
+	Short:   "remove unused versions and downloaded archives",
+	Long:    long,
+	Example: example,
+	Run:     run,
+}
+
+// Command description
+var long = `Remove versions which are neither current nor pinned by the projects
+in the project roots, along with downloaded archives and build workspaces
+
+Nothing is removed by default, only the plan is shown, use "--dry-run=false" to apply it.
+In the system mode versions used by other users can't be known, so "--force" is required`
+
+// Command example
+var example = `
+  Show what would be removed
+  $ ec prune
+
+  Keep the newest version of every major and versions pinned by the projects
+  $ ec prune --keep 1 --root ~/projects
+
+  Remove unused versions
+  $ ec prune --dry-run=false`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	items, err := ePrune.Plan(&ePrune.Rules{
+		Keep:        keep,
+		KeepAliases: keepAliases,
+		Roots:       roots,
+		Force:       isForce,
+	})
+	print.Error(err)
+
+	if len(items) == 0 {
+		print.Green("There is nothing to prune")
+		print.LastPrint()
+		return
+	}
+
+	var (
+		rows  = [][]string{}
+		total uint64
+	)
+
+	for _, item := range items {
+		if isDryRun == false {
+			err = item.Remove()
+			print.Error(err)
+		}
+
+		total += uint64(item.Size)
+		rows = append(rows, []string{item.Kind, item.Language, item.Version, humanize.Bytes(uint64(item.Size))})
+	}
+
+	print.Table([]string{"kind", "language", "version", "size"}, rows)
+
+	if isDryRun {
+		print.Green(humanize.Bytes(total) + " could be reclaimed, use \"--dry-run=false\" to remove these")
+	} else {
+		print.Green(humanize.Bytes(total) + " reclaimed")
+	}
+
+	print.LastPrint()
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+
+	flags.BoolVarP(&isDryRun, "dry-run", "d", true, "show what would be removed instead of removing it")
+	flags.IntVarP(&keep, "keep", "k", 0, "how many newest versions are kept for every major")
+	flags.BoolVarP(&keepAliases, "keep-aliases", "a", true, "keep versions the aliases point to")
+	flags.BoolVarP(&isForce, "force", "f", false, "prune in the system mode, even though versions used by other users might be removed")
+	flags.StringSliceVarP(&roots, "root", "t", variables.ProjectRoots(), "folder with projects which pinned versions are kept")
+}
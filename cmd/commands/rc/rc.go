@@ -0,0 +1,100 @@
+// Package rc defines "rc" command i.e. sets up eclectica in the shell startup files
+package rc
+
+import (
+	"fmt"
+
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/print"
+	eRc "github.com/markelog/eclectica/rc"
+)
+
+// Command config
+var Command = &cobra.Command{
+	Use:     "rc",
+	Short:   "add eclectica to the shell startup files",
+	Example: example,
+	Run:     run,
+}
+
+// Command example
+var example = `
+  Show what would be changed in the startup files
+  $ ec rc --dry-run
+
+  Remove eclectica from the startup files
+  $ ec rc --remove`
+
+// Only show the changes?
+var isDryRun bool
+
+// Remove eclectica from the rc files?
+var isRemove bool
+
+func run(c *cobra.Command, args []string) {
+	var (
+		rc      = eRc.New()
+		changes []*eRc.Change
+		err     error
+	)
+
+	if isRemove {
+		changes, err = rc.RemoveChanges()
+	} else {
+		changes, err = rc.AddChanges()
+	}
+	print.Error(err)
+
+	if isDryRun {
+		show(changes)
+		return
+	}
+
+	err = eRc.Apply(changes)
+	print.Error(err)
+
+	for _, change := range changes {
+		if change.Before != change.After {
+			print.Green("Updated \"" + change.Path + "\", original is backed up near it")
+		}
+	}
+}
+
+// show prints diff of the changes
+func show(changes []*eRc.Change) {
+	isChanged := false
+
+	for _, change := range changes {
+		if change.Before == change.After {
+			continue
+		}
+
+		isChanged = true
+
+		fmt.Println()
+		fmt.Println(ansi.Color(change.Path, "white+b"))
+
+		for _, line := range change.Diff() {
+			switch line[0] {
+			case '+':
+				fmt.Println(ansi.Color(line, "green"))
+			case '-':
+				fmt.Println(ansi.Color(line, "red"))
+			default:
+				fmt.Println(print.Gray + line + print.Reset)
+			}
+		}
+	}
+
+	if isChanged == false {
+		fmt.Println("Nothing to change")
+	}
+}
+
+func init() {
+	flags := Command.PersistentFlags()
+	flags.BoolVarP(&isDryRun, "dry-run", "d", false, "show the changes instead of applying them")
+	flags.BoolVarP(&isRemove, "remove", "", false, "remove eclectica from the startup files")
+}
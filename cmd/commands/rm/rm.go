@@ -2,71 +2,212 @@
 package rm
 
 import (
+	"strings"
+
+	"github.com/go-errors/errors"
 	"github.com/schollz/closestmatch"
 	"github.com/spf13/cobra"
 
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/complete"
 	"github.com/markelog/eclectica/cmd/info"
 	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/list"
 	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/versions"
 )
 
+// Keep the current versions?
+var exceptCurrent bool
+
+// Allow to remove the current versions?
+var isForce bool
+
+// Assume yes to the prompt
+var assumeYes bool
+
 // Command config
 var Command = &cobra.Command{
-	Use:     "rm [<language>@<version>]",
-	Aliases: []string{"remove"},
-	Short:   "remove language version",
-	Example: example,
-	Run:     run,
+	Use:               "rm [<language>@<version>...]",
+	Aliases:           []string{"remove"},
+	Short:             "remove language versions",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Versions,
 }
 
 // Command example
 var example = `
-  Install specifc version
+  Remove specifc version
   $ ec rm rust@1.11.0
 
+  Remove several versions
+  $ ec rm node@6.1.0 node@7.2.0
+
+  Remove every installed 6.x version, except the current one
+  $ ec rm node@6 --except-current
+
   Remove language version with interactive list
   $ ec rm go
 
   Remove with interactive list
   $ ec rm`
 
+// target is the installed version which should be removed
+type target struct {
+	language string
+	version  string
+}
+
 // Runner
 func run(cmd *cobra.Command, args []string) {
 	var (
-		err               error
-		language, version = info.GetLanguage(args)
-		hasLanguage       = info.HasLanguage(args)
-		hasVersion        = info.HasVersion(args)
-		cm                = closestmatch.New(plugins.Plugins, []int{2})
+		err       error
+		targets   []*target
+		seen      = map[string]bool{}
+		isPartial bool
+		cm        = closestmatch.New(plugins.Plugins, []int{2})
 	)
 
-	// Searching for closest plugin name
-	if len(args) > 0 && hasLanguage == false {
-		possible := info.PossibleLanguage(args)
-		print.ClosestLangWarning(possible, cm.Closest(possible))
-		return
+	for _, arg := range args {
+		if info.HasLanguage([]string{arg}) == false {
+			possible := info.PossibleLanguage([]string{arg})
+			print.ClosestLangWarning(possible, cm.Closest(possible))
+			return
+		}
 	}
 
-	if hasVersion == false {
-		if hasLanguage {
+	// Ask for the version, the way it was always done
+	if len(args) < 2 && info.HasVersion(args) == false {
+		var language, version string
+
+		if len(args) == 1 {
+			language, _ = info.GetLanguage(args)
 			print.FnInStyleln("langauge:", language)
 			version, err = info.AskVersion(language)
 		} else {
 			language, version, err = info.Ask()
 		}
+
+		print.Error(err)
+
+		args = []string{language + "@" + version}
 	}
 
-	print.Error(err)
+	for _, arg := range args {
+		language, version := info.GetLanguage([]string{arg})
+
+		if version == "" {
+			print.Error(errors.New("Version for \"" + arg + "\" should be defined like \"" + language + "@6\""))
+		}
+
+		version = aliases.Resolve(language, version)
+		isPartial = isPartial || versions.IsPartial(version)
+
+		found, err := find(language, version)
+		print.Error(err)
+
+		// Masks might match the same versions, like "node@6 node@6.1.0"
+		for _, item := range found {
+			key := item.language + "@" + item.version
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			targets = append(targets, item)
+		}
+	}
+
+	if len(targets) == 0 {
+		print.Green("There is nothing to remove")
+		print.LastPrint()
+		return
+	}
+
+	if isPartial && assumeYes == false {
+		names := []string{}
+		for _, item := range targets {
+			names = append(names, item.language+"@"+item.version)
+		}
 
-	remove(language, version)
+		print.InStyleln("Versions", strings.Join(names, ", "))
+
+		response := list.List("Remove these versions?", []string{"yes", "no"}, 0)
+		if response == "no" {
+			return
+		}
+	}
+
+	for _, item := range targets {
+		remove(item)
+	}
+
+	print.LastPrint()
 }
 
-// Try to remove
-func remove(language, version string) {
-	err := plugins.New(&plugins.Args{
+// find gets installed versions of the language which match the version,
+// current version is either skipped or should be removed explicitly
+func find(language, version string) (result []*target, err error) {
+	plugin := plugins.New(&plugins.Args{
 		Language: language,
-		Version:  version,
-	}).Remove()
+	})
+
+	current := plugin.Current()
+	matched := versions.Match(version, plugin.List())
+
+	if len(matched) == 0 {
+		return nil, errors.New("Version \"" + version + "\" of " + language + " is not installed")
+	}
+
+	for _, match := range matched {
+		if match == current {
+			if exceptCurrent {
+				continue
+			}
+
+			if isForce == false {
+				return nil, errors.New(
+					"\"" + language + "@" + match + "\" is the current version, use \"--force\" to remove it " +
+						"or \"--except-current\" to keep it",
+				)
+			}
+		}
+
+		result = append(result, &target{language, match})
+	}
+
+	return
+}
+
+// remove removes the version, the language is left without
+// current version if it was the current one
+func remove(item *target) {
+	plugin := plugins.New(&plugins.Args{
+		Language: item.language,
+		Version:  item.version,
+	})
+
+	isCurrent := plugin.Current() == item.version
+
+	err := plugin.Remove()
 	print.Error(err)
-	print.LastPrint()
+
+	print.InStyleln("Removed", item.language+"@"+item.version)
+
+	if isCurrent {
+		print.Warning(
+			item.language+" does not have current version anymore",
+			"ec "+item.language,
+		)
+	}
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+
+	flags.BoolVarP(&exceptCurrent, "except-current", "e", false, "keep the current versions")
+	flags.BoolVarP(&isForce, "force", "f", false, "allow to remove the current versions")
+	flags.BoolVarP(&assumeYes, "assume-yes", "y", false, "assume yes to the prompt")
 }
@@ -5,11 +5,17 @@ const example = `
   Install specifc version
   $ ec node@6.4.0
 
+  Install several versions at once
+  $ ec node@8 go@1.9 python@3.6
+
   Choose local version with interactive list
   $ ec go
 
   Choose remote version with interactive list
-  $ ec -r rust`
+  $ ec -r rust
+
+  Install everything defined by the project dot files
+  $ ec install --project`
 
 // Help output
 const help = `
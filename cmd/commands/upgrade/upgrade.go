@@ -0,0 +1,286 @@
+// Package upgrade defines "upgrade" command i.e. moves to the newest release within a version line
+package upgrade
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/events"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/shell"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+// Only show the planned upgrades?
+var isDryRun bool
+
+// Remove upgraded versions?
+var removeOld bool
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "upgrade [<language>@<version>...]",
+	Short:             "upgrade to the newest release within the version line",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Versions,
+}
+
+// Command example
+var example = `
+  Upgrade current versions of all languages to their newest patch releases
+  $ ec upgrade
+
+  Upgrade the newest installed 8.x node.js to the newest 8.x release
+  $ ec upgrade node@8
+
+  Upgrade current go version and remove the old one
+  $ ec upgrade go --remove-old
+
+  Show the planned upgrades
+  $ ec upgrade --dry-run`
+
+// Move is the planned upgrade of the language version
+type Move struct {
+	Language string
+	From     string
+	To       string
+
+	// Is From the current version?
+	Current bool
+}
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	cm := closestmatch.New(plugins.Plugins, []int{2})
+	moves := []*Move{}
+
+	for _, arg := range args {
+		language, version := info.GetLanguage([]string{arg})
+
+		// Searching for closest plugin name
+		if language == "" {
+			possible := info.PossibleLanguage([]string{arg})
+			print.ClosestLangWarning(possible, cm.Closest(possible))
+			return
+		}
+
+		move, err := plan(language, version)
+		print.Error(err)
+
+		moves = append(moves, move)
+	}
+
+	// In case of `ec upgrade` – every current selection
+	if len(args) == 0 {
+		for _, language := range plugins.Plugins {
+			current := variables.CurrentVersion(language)
+			if current == "" || current == versions.System {
+				continue
+			}
+
+			// One version which can't be upgraded should not stop the others
+			move, err := plan(language, "")
+			if err != nil {
+				print.Warning("Skipping "+language+": "+err.Error(), "")
+				continue
+			}
+
+			moves = append(moves, move)
+		}
+	}
+
+	if len(moves) == 0 {
+		print.Error(errors.New("There is no installed versions"))
+	}
+
+	printPlan(moves)
+
+	if isDryRun || isUpToDate(moves) {
+		print.LastPrint()
+		return
+	}
+
+	init := shell.New(plugins.Plugins)
+	init.Check()
+
+	err := init.Initiate()
+	print.Error(err)
+
+	for _, move := range moves {
+		if move.From != move.To {
+			upgrade(move)
+		}
+	}
+
+	print.LastPrint()
+
+	// Start new shell from eclectica if needed
+	// note: should be the last action
+	init.Start()
+}
+
+// plan finds the newest remote version in the line of the installed one,
+// line is defined by the mask, like "8", or by major and minor of the version
+func plan(language, mask string) (*Move, error) {
+	var (
+		from   string
+		err    error
+		plugin = plugins.New(&plugins.Args{
+			Language: language,
+		})
+		current = plugin.Current()
+	)
+
+	mask = aliases.Resolve(language, mask)
+
+	switch {
+	case mask == "":
+		if current == "" || current == versions.System {
+			return nil, errors.New("There is no installed " + language + " versions")
+		}
+
+		from = current
+	case versions.IsPartial(mask) == false:
+		if variables.IsInstalled(language, mask) == false {
+			return nil, errors.New("Version " + mask + " of " + language + " is not installed")
+		}
+
+		from = mask
+	default:
+		// Current version is preferred if it's in the line
+		if found, errLatest := versions.Latest(mask, []string{current}); errLatest == nil && found == current {
+			from = current
+			break
+		}
+
+		from, err = versions.Latest(mask, plugin.List())
+		if err != nil {
+			return nil, errors.New("None of the installed " + language + " versions match \"" + mask + "\"")
+		}
+	}
+
+	// Without the mask, line of the version is used
+	if mask == "" || versions.IsPartial(mask) == false {
+		mask = versions.Line(from)
+	}
+
+	if variables.LinkTarget(language, from) != "" {
+		return nil, errors.New("Version " + from + " of " + language + " is linked, it is not upgraded by eclectica")
+	}
+
+	parsed, err := versions.Parse(from)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := plugin.RemoteVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	move := &Move{
+		Language: language,
+		From:     from,
+		To:       from,
+		Current:  from == current,
+	}
+
+	newest, err := versions.Newest(mask, remote, parsed.IsPrerelease())
+	if err != nil {
+		return move, nil
+	}
+
+	if newestParsed, errParse := versions.Parse(newest); errParse == nil && parsed.LessThan(newestParsed) {
+		move.To = newest
+	}
+
+	return move, nil
+}
+
+// upgrade installs the newest version and switches to it, if the old one was the current,
+// global modules of node.js are reinstalled from the old version in that case
+func upgrade(move *Move) {
+	plugin := plugins.New(&plugins.Args{
+		Language:    move.Language,
+		Version:     move.To,
+		WithModules: move.Current,
+	})
+
+	print.FnInStyleln("language:", move.Language)
+	print.InStyleln(" version:", move.To)
+
+	err := plugin.PreDownload()
+	print.Error(err)
+
+	response, err := plugin.Download()
+	print.Error(err)
+
+	// response == nil means we already downloaded that thing
+	if response != nil {
+		print.Download(response, move.To)
+		print.Error(response.Error)
+
+		err = plugin.Extract()
+		print.Error(err)
+	}
+
+	events.Setup(plugin)
+
+	err = plugin.BareInstall()
+	print.Error(err)
+
+	if move.Current {
+		err = plugin.Activate(false)
+		print.Error(err)
+	}
+
+	if removeOld {
+		err = plugins.New(&plugins.Args{
+			Language: move.Language,
+			Version:  move.From,
+		}).Remove()
+		print.Error(err)
+	}
+}
+
+// printPlan prints the table with the planned upgrades
+func printPlan(moves []*Move) {
+	rows := [][]string{}
+
+	for _, move := range moves {
+		to := move.To
+		if move.From == move.To {
+			to = "up to date"
+		}
+
+		rows = append(rows, []string{move.Language, move.From, to})
+	}
+
+	print.Table([]string{"language", "installed", "upgrade"}, rows)
+}
+
+// isUpToDate checks if there is nothing to upgrade
+func isUpToDate(moves []*Move) bool {
+	for _, move := range moves {
+		if move.From != move.To {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+
+	flags.BoolVarP(&isDryRun, "dry-run", "d", false, "show the planned upgrades instead of applying them")
+	flags.BoolVarP(&removeOld, "remove-old", "o", false, "remove the upgraded versions")
+}
@@ -0,0 +1,117 @@
+// Package use defines "use" command i.e. switches between installed versions
+// without ever downloading anything
+package use
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/schollz/closestmatch"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/cmd/events"
+	"github.com/markelog/eclectica/cmd/info"
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/shell"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Switch for the current folder only?
+var isLocal bool
+
+// Format of the dot file for the local version
+var format string
+
+// Command config
+var Command = &cobra.Command{
+	Use:               "use <language>@<version>",
+	Short:             "switch to the installed version",
+	Example:           example,
+	Run:               run,
+	ValidArgsFunction: complete.Versions,
+}
+
+// Command example
+var example = `
+  Switch to the installed version
+  $ ec use node@8.9.4
+
+  Switch to the newest installed 8.x version
+  $ ec use node@8
+
+  Switch to the version installed by the operating system
+  $ ec use python@system
+
+  Switch for the current folder only
+  $ ec use go@1.9 --local`
+
+// Runner
+func run(cmd *cobra.Command, args []string) {
+	var (
+		err               error
+		language, version = info.GetLanguage(args)
+		cm                = closestmatch.New(plugins.Plugins, []int{2})
+	)
+
+	if len(args) != 1 {
+		print.Error(errors.New("Version should be defined like \"node@8.9.4\""))
+	}
+
+	// Searching for closest plugin name
+	if info.HasLanguage(args) == false {
+		possible := info.PossibleLanguage(args)
+		print.ClosestLangWarning(possible, cm.Closest(possible))
+		return
+	}
+
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+	})
+
+	print.FnInStyleln("language:", language)
+
+	if version == "" {
+		version, err = info.AskVersion(language)
+		print.Error(err)
+	}
+
+	version, err = plugin.Installed(version)
+	print.Error(err)
+
+	print.InStyleln(" version:", version)
+
+	activate(language, version)
+}
+
+// activate switches to the version, shell is initiated the same way as for the installation
+func activate(language, version string) {
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+		Version:  version,
+		Format:   format,
+	})
+
+	init := shell.New(plugins.Plugins)
+	init.Check()
+
+	err := init.Initiate()
+	print.Error(err)
+
+	events.Setup(plugin)
+
+	err = plugin.Activate(isLocal)
+	print.Error(err)
+
+	print.LastPrint()
+
+	// Start new shell from eclectica if needed
+	// note: should be the last action
+	init.Start()
+}
+
+// Init
+func init() {
+	flags := Command.PersistentFlags()
+	flags.BoolVarP(&isLocal, "local", "l", false, "switch for the current folder only")
+	flags.StringVarP(&format, "format", "", variables.LocalFormat(), "format of the dot file for the local version, \"auto\", \"language\" or \"tool-versions\"")
+}
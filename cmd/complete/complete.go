@@ -0,0 +1,91 @@
+// Package complete provides dynamic completions of the languages and their versions,
+// nothing is requested here, since completion is executed on every key press
+package complete
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Languages completes names of the supported languages
+func Languages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withPrefix(plugins.Plugins, "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Versions completes "<language>@<version>" arguments, with installed versions and aliases
+// or with the cached remote ones if "--remote" flag is present
+func Versions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	parts := strings.SplitN(toComplete, "@", 2)
+
+	// Wait for the version after the language
+	if len(parts) == 1 {
+		var result []string
+
+		for _, language := range withPrefix(plugins.Plugins, "", toComplete) {
+			result = append(result, language+"@")
+		}
+
+		if len(result) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return result, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+
+	language := parts[0]
+	if isSupported(language) == false {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+	})
+
+	vers := append(plugin.List(), Aliases(language, "")...)
+
+	if isRemote, _ := cmd.Flags().GetBool("remote"); isRemote {
+		vers = plugin.CachedVersions()
+	}
+
+	return withPrefix(vers, language+"@", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Aliases completes names of the language aliases
+func Aliases(language, toComplete string) []string {
+	defined, _ := aliases.List(language)
+
+	names := []string{}
+	for name := range defined {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return withPrefix(names, "", toComplete)
+}
+
+// withPrefix gets prefixed elements of the list which start with the completed value
+func withPrefix(list []string, prefix, toComplete string) (result []string) {
+	for _, elem := range list {
+		if strings.HasPrefix(prefix+elem, toComplete) {
+			result = append(result, prefix+elem)
+		}
+	}
+
+	return
+}
+
+func isSupported(language string) bool {
+	for _, elem := range plugins.Plugins {
+		if elem == language {
+			return true
+		}
+	}
+
+	return false
+}
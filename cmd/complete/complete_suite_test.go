@@ -0,0 +1,13 @@
+package complete_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Complete Suite")
+}
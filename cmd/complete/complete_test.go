@@ -0,0 +1,99 @@
+package complete_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/markelog/eclectica/aliases"
+	. "github.com/markelog/eclectica/cmd/complete"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("complete", func() {
+	var (
+		home string
+		cmd  *cobra.Command
+	)
+
+	BeforeEach(func() {
+		home = filepath.Join(os.TempDir(), "eclectica-complete")
+		os.Setenv("EC_HOME", home)
+
+		for _, version := range []string{"6.1.0", "7.0.0"} {
+			os.MkdirAll(variables.Path("node", version), 0755)
+			variables.WriteVersion("node", version)
+		}
+
+		io.CreateDir(variables.Cache())
+		io.WriteFile(filepath.Join(variables.Cache(), "node"), "8.0.0\n8.1.0")
+
+		cmd = &cobra.Command{}
+		cmd.Flags().BoolP("remote", "r", false, "")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_HOME")
+		os.RemoveAll(home)
+	})
+
+	Describe("Languages", func() {
+		It("should complete the language", func() {
+			result, _ := Languages(cmd, []string{}, "ru")
+
+			Expect(result).To(Equal([]string{"rust", "ruby"}))
+		})
+	})
+
+	Describe("Versions", func() {
+		It("should complete the language with the version separator", func() {
+			result, directive := Versions(cmd, []string{}, "no")
+
+			Expect(result).To(Equal([]string{"node@"}))
+			Expect(directive & cobra.ShellCompDirectiveNoSpace).NotTo(BeZero())
+		})
+
+		It("should complete installed versions", func() {
+			result, _ := Versions(cmd, []string{}, "node@6")
+
+			Expect(result).To(Equal([]string{"node@6.1.0"}))
+		})
+
+		It("should complete aliases", func() {
+			aliases.Set("node", "legacy", "6.1.0")
+
+			result, _ := Versions(cmd, []string{}, "node@")
+
+			Expect(result).To(Equal([]string{"node@7.0.0", "node@6.1.0", "node@legacy"}))
+		})
+
+		It("should complete cached remote versions", func() {
+			cmd.Flags().Set("remote", "true")
+
+			result, _ := Versions(cmd, []string{}, "node@")
+
+			Expect(result).To(Equal([]string{"node@8.0.0", "node@8.1.0"}))
+		})
+
+		It("should not complete unknown language", func() {
+			result, _ := Versions(cmd, []string{}, "nod@")
+
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Describe("Aliases", func() {
+		It("should complete aliases of the language", func() {
+			aliases.Set("node", "legacy", "6.1.0")
+			aliases.Set("node", "work", "7.0.0")
+			aliases.Set("go", "lab", "1.9.0")
+
+			Expect(Aliases("node", "")).To(Equal([]string{"legacy", "work"}))
+			Expect(Aliases("node", "w")).To(Equal([]string{"work"}))
+		})
+	})
+})
@@ -1,12 +1,17 @@
-package install
+// Package events shows installation events of the plugins with the spinner,
+// kept apart from the commands, so the proxy could use it too
+package events
 
 import (
 	"github.com/markelog/eclectica/cmd/print/custom-spinner"
 	"github.com/markelog/eclectica/plugins"
 )
 
-// SetupEvents sets events
-func SetupEvents(plugin *plugins.Plugin) {
+// Event type handler
+type handleFn func(args ...string)
+
+// Setup sets events
+func Setup(plugin *plugins.Plugin) {
 	var spinner *CustomSpinner.Spin
 
 	handle := func(note string) handleFn {
@@ -153,7 +153,7 @@ func ListRemote(language string) (versions map[string][]string, err error) {
 	return
 }
 
-// FullListRemote lists remote version from the plugin directly
+// FullListRemote lists remote versions without composing them
 func FullListRemote(language string) (versions []string, err error) {
 	plugin := plugins.New(&plugins.Args{
 		Language: language,
@@ -166,7 +166,7 @@ func FullListRemote(language string) (versions []string, err error) {
 	})
 
 	s.Start()
-	versions, err = plugin.Pkg.ListRemote()
+	versions, err = plugin.RemoteVersions()
 	s.Stop()
 
 	return
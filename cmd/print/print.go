@@ -14,9 +14,9 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/markelog/curse"
 	"github.com/mgutz/ansi"
-	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/eclectica/cmd/print/spinner"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -105,10 +105,9 @@ func Error(err error) {
 	os.Exit(1)
 }
 
-// Download continuously prints download info
-func Download(response *grab.Response, version string) string {
-	Error(response.Error)
-
+// Download continuously prints download info,
+// note: consumer should check response.Error afterwards
+func Download(response *request.Response, version string) string {
 	cursed, _ := curse.New()
 
 	sizeAndTransfer := func() (size, transfer string) {
@@ -143,8 +142,6 @@ func Download(response *grab.Response, version string) string {
 	}
 
 	after := func() {
-		Error(response.Error)
-
 		cursed.MoveUp(1)
 		cursed.EraseCurrentLine()
 		InStyleln(" version:", version)
@@ -164,6 +161,36 @@ func Download(response *grab.Response, version string) string {
 	return response.Filename
 }
 
+// Table prints rows aligned by columns with the header in style
+func Table(header []string, rows [][]string) {
+	widths := make([]int, len(header))
+
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	line := func(row []string) string {
+		cells := make([]string, len(row))
+
+		for i, cell := range row {
+			cells[i] = cell + strings.Repeat(" ", widths[i]-len(cell))
+		}
+
+		return "  " + strings.TrimRight(strings.Join(cells, "   "), " ")
+	}
+
+	fmt.Println()
+	fmt.Println(ansi.Color(line(header), "white+b"))
+
+	for _, row := range rows {
+		fmt.Println(Gray + line(row) + Reset)
+	}
+}
+
 func Green(msg string) {
 	fmt.Println()
 	fmt.Println(ansi.Color("> ", "green") + msg)
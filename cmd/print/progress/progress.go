@@ -0,0 +1,249 @@
+// Package progress renders progress of several simultaneous tasks, one line per task
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/markelog/curse"
+	"github.com/mgutz/ansi"
+	spin "github.com/tj/go-spin"
+
+	"github.com/markelog/eclectica/cmd/print"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+)
+
+var (
+	gray    = print.Gray
+	white   = print.White
+	reset   = print.Reset
+	timeout = print.Timeout
+)
+
+// Line states
+const (
+	running = iota
+	succeeded
+	failed
+)
+
+// Progress essential struct
+type Progress struct {
+	Lines []*Line
+
+	spin     *spin.Spinner
+	mutex    *sync.Mutex
+	drawn    int
+	isDone   bool
+	finished chan bool
+}
+
+// Line is the progress of one task
+type Line struct {
+	Header, Item, Note, Message string
+
+	state    int
+	response *request.Response
+	printed  bool
+	mutex    *sync.Mutex
+}
+
+// New returns new progress struct
+func New() *Progress {
+	return &Progress{
+		Lines: []*Line{},
+
+		spin:     spin.New(),
+		mutex:    &sync.Mutex{},
+		finished: make(chan bool),
+	}
+}
+
+// Add adds new line to the progress
+func (progress *Progress) Add(header, item string) *Line {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	line := &Line{
+		Header: header,
+		Item:   item,
+		mutex:  &sync.Mutex{},
+	}
+
+	progress.Lines = append(progress.Lines, line)
+
+	return line
+}
+
+// Start rendering the progress
+func (progress *Progress) Start() {
+	if variables.WithoutSpinner() {
+		go progress.plain()
+		return
+	}
+
+	go progress.animate()
+}
+
+// Stop rendering the progress and print final state of the lines
+func (progress *Progress) Stop() {
+	progress.mutex.Lock()
+	progress.isDone = true
+	progress.mutex.Unlock()
+
+	<-progress.finished
+}
+
+// animate redraws all the lines until stopped
+func (progress *Progress) animate() {
+	cursed, _ := curse.New()
+
+	for {
+		progress.mutex.Lock()
+
+		if progress.drawn > 0 {
+			cursed.MoveUp(progress.drawn)
+		}
+
+		frame := progress.spin.Next()
+		for _, line := range progress.Lines {
+			cursed.EraseCurrentLine()
+			fmt.Println(line.String(frame))
+		}
+
+		progress.drawn = len(progress.Lines)
+		isDone := progress.isDone
+
+		progress.mutex.Unlock()
+
+		if isDone {
+			break
+		}
+
+		time.Sleep(timeout)
+	}
+
+	progress.finished <- true
+}
+
+// plain prints lines only when they are done,
+// for the cases when terminal can't (or shouldn't) be redrawn
+func (progress *Progress) plain() {
+	for {
+		progress.mutex.Lock()
+
+		for _, line := range progress.Lines {
+			line.mutex.Lock()
+			shouldPrint := line.state != running && line.printed == false
+			line.mutex.Unlock()
+
+			if shouldPrint {
+				fmt.Println(line.String(""))
+
+				line.mutex.Lock()
+				line.printed = true
+				line.mutex.Unlock()
+			}
+		}
+
+		isDone := progress.isDone
+
+		progress.mutex.Unlock()
+
+		if isDone {
+			break
+		}
+
+		time.Sleep(timeout)
+	}
+
+	progress.finished <- true
+}
+
+// SetItem sets new item for the line
+func (line *Line) SetItem(item string) {
+	line.mutex.Lock()
+	defer line.mutex.Unlock()
+
+	line.Item = item
+}
+
+// Set new note and message for the line
+func (line *Line) Set(note, message string) {
+	line.mutex.Lock()
+	defer line.mutex.Unlock()
+
+	line.Note = note
+	line.Message = message
+	line.response = nil
+}
+
+// Download shows download progress on the line
+func (line *Line) Download(response *request.Response) {
+	line.mutex.Lock()
+	defer line.mutex.Unlock()
+
+	line.Note = "download"
+	line.Message = ""
+	line.response = response
+}
+
+// Succeed marks the line as successfully finished
+func (line *Line) Succeed(note string) {
+	line.finish(succeeded, note, "")
+}
+
+// Fail marks the line as failed
+func (line *Line) Fail(err error) {
+	line.finish(failed, "failed", err.Error())
+}
+
+func (line *Line) finish(state int, note, message string) {
+	line.mutex.Lock()
+	defer line.mutex.Unlock()
+
+	line.state = state
+	line.Note = note
+	line.Message = message
+	line.response = nil
+}
+
+// String renders the line with provided spinner frame
+func (line *Line) String(frame string) string {
+	line.mutex.Lock()
+	defer line.mutex.Unlock()
+
+	var (
+		mark    = ansi.Color(frame, "cyan")
+		header  = ansi.Color(line.Header, "white+b")
+		item    = ansi.Color(" "+line.Item+" ", "cyan+h")
+		message = line.Message
+	)
+
+	switch line.state {
+	case succeeded:
+		mark = ansi.Color("✓", "green")
+	case failed:
+		mark = ansi.Color("✗", "red")
+	}
+
+	if line.response != nil {
+		size := humanize.Bytes(line.response.Size)
+		transfer := humanize.Bytes(line.response.BytesTransferred())
+		transfer = strings.Replace(transfer, " MB", "", 1)
+
+		message = fmt.Sprintf(
+			"%s/%s %d%%", transfer, size, int(100*line.response.Progress()),
+		)
+	}
+
+	if len(message) > 0 {
+		message = white + "(" + gray + message + white + ")"
+	}
+
+	return fmt.Sprint(header, item, reset, mark, gray, " ", line.Note, " ", message, reset)
+}
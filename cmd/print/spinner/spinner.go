@@ -3,11 +3,12 @@ package spinner
 
 import (
 	"fmt"
-	"os"
 	"sync"
 
 	"github.com/mgutz/ansi"
 	spin "github.com/tj/go-spin"
+
+	"github.com/markelog/eclectica/variables"
 )
 
 // Spinner essential struct
@@ -43,7 +44,7 @@ func (spinner *Spinner) Start() {
 	spinner.mutex.Lock()
 	defer spinner.mutex.Unlock()
 
-	if os.Getenv("EC_WITHOUT_SPINNER") == "true" {
+	if variables.WithoutSpinner() {
 		spinner.isDone = true
 		return
 	}
@@ -0,0 +1,441 @@
+// Package config provides structure of the eclectica configuration file
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+)
+
+var (
+
+	// Keys are names of all settings, except the mirror ones,
+	// which are defined as "mirrors.<name>.<field>"
+	Keys = []string{
+		"auto-activate",
+		"auto-install",
+		"cache-ttl",
+		"connect-timeout",
+		"debug",
+		"jobs",
+		"local-format",
+		"project-roots",
+		"proxy-place",
+		"read-timeout",
+		"restart-shell",
+		"retries",
+		"system-root",
+		"with-modules",
+		"without-spinner",
+	}
+
+	// MirrorFields are names of all mirror settings
+	MirrorFields = []string{"url", "list", "username", "password", "token"}
+
+	// LocalFormats are possible formats of the dot files for the local versions
+	LocalFormats = []string{"auto", "language", "tool-versions"}
+)
+
+// Config essential struct
+type Config struct {
+
+	// AutoActivate makes "ec init" snippet switch versions on directory change
+	AutoActivate bool `toml:"auto-activate,omitempty"`
+
+	// AutoInstall makes proxy install the version defined in the dot file if it's missing
+	AutoInstall bool `toml:"auto-install,omitempty"`
+
+	// CacheTTL is how long list of the remote versions is cached, like "10m"
+	CacheTTL string `toml:"cache-ttl,omitempty"`
+
+	// ConnectTimeout is how long connection to the server is established, like "30s"
+	ConnectTimeout string `toml:"connect-timeout,omitempty"`
+
+	// Debug prints more info when executing commands
+	Debug bool `toml:"debug,omitempty"`
+
+	// Jobs is how many jobs are executed simultaneously when language is compiled
+	Jobs int `toml:"jobs,omitempty"`
+
+	// LocalFormat is the dot file format for the local versions
+	LocalFormat string `toml:"local-format,omitempty"`
+
+	// ProjectRoots are the folders with projects, separated like in the PATH,
+	// versions pinned by these projects are not pruned
+	ProjectRoots string `toml:"project-roots,omitempty"`
+
+	// ProxyPlace is the folder where ec-proxy binary is located
+	ProxyPlace string `toml:"proxy-place,omitempty"`
+
+	// ReadTimeout is how long data from the server is waited for, like "1m"
+	ReadTimeout string `toml:"read-timeout,omitempty"`
+
+	// RestartShell starts new shell when eclectica is not yet activated in the current one
+	RestartShell bool `toml:"restart-shell,omitempty"`
+
+	// Retries is how many times failed request is retried, zero disables the retries
+	Retries *int `toml:"retries,omitempty"`
+
+	// SystemRoot is the folder where languages are installed for all users
+	SystemRoot string `toml:"system-root,omitempty"`
+
+	// WithModules reinstalls global modules from the previous version
+	WithModules bool `toml:"with-modules,omitempty"`
+
+	// WithoutSpinner disables spinners, useful for the CI
+	WithoutSpinner bool `toml:"without-spinner,omitempty"`
+
+	Mirrors map[string]*Mirror `toml:"mirrors,omitempty"`
+}
+
+// Mirror defines alternative location of the language distributions
+type Mirror struct {
+
+	// URL replaces the link from which distributions are downloaded
+	URL string `toml:"url,omitempty"`
+
+	// List replaces the link from which list of the versions is taken
+	List string `toml:"list,omitempty"`
+
+	// Username and Password are used for the basic authentication
+	Username string `toml:"username,omitempty"`
+	Password string `toml:"password,omitempty"`
+
+	// Token is used for the bearer authentication
+	Token string `toml:"token,omitempty"`
+}
+
+// Load reads configuration from the provided path,
+// absence of the file is not considered an error
+func Load(path string) (*Config, error) {
+	config := &Config{}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return config, nil
+	}
+
+	_, err := toml.DecodeFile(path, config)
+	if err != nil {
+		return config, errors.New("Configuration file \"" + path + "\" is malformed: " + err.Error())
+	}
+
+	return config, nil
+}
+
+// Save writes configuration to the provided path
+func Save(path string, config *Config) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// File might contain credentials of the mirrors
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close()
+
+	err = toml.NewEncoder(file).Encode(config)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Get gets value of the setting
+func (config *Config) Get(key string) (string, error) {
+	if name, field, ok := mirrorKey(key); ok {
+		return config.Mirrors[name].get(field)
+	}
+
+	switch key {
+	case "auto-activate":
+		return strconv.FormatBool(config.AutoActivate), nil
+	case "auto-install":
+		return strconv.FormatBool(config.AutoInstall), nil
+	case "cache-ttl":
+		return config.CacheTTL, nil
+	case "connect-timeout":
+		return config.ConnectTimeout, nil
+	case "debug":
+		return strconv.FormatBool(config.Debug), nil
+	case "jobs":
+		return strconv.Itoa(config.Jobs), nil
+	case "local-format":
+		return config.LocalFormat, nil
+	case "project-roots":
+		return config.ProjectRoots, nil
+	case "proxy-place":
+		return config.ProxyPlace, nil
+	case "read-timeout":
+		return config.ReadTimeout, nil
+	case "restart-shell":
+		return strconv.FormatBool(config.RestartShell), nil
+	case "retries":
+		if config.Retries == nil {
+			return "", nil
+		}
+
+		return strconv.Itoa(*config.Retries), nil
+	case "system-root":
+		return config.SystemRoot, nil
+	case "with-modules":
+		return strconv.FormatBool(config.WithModules), nil
+	case "without-spinner":
+		return strconv.FormatBool(config.WithoutSpinner), nil
+	}
+
+	return "", unknown(key)
+}
+
+// Set validates and sets value of the setting, empty value resets it
+func (config *Config) Set(key, value string) (err error) {
+	if name, field, ok := mirrorKey(key); ok {
+		return config.setMirror(name, field, value)
+	}
+
+	switch key {
+	case "auto-activate":
+		config.AutoActivate, err = parseBool(value)
+	case "auto-install":
+		config.AutoInstall, err = parseBool(value)
+	case "cache-ttl":
+		config.CacheTTL, err = parseDuration(value)
+	case "connect-timeout":
+		config.ConnectTimeout, err = parseDuration(value)
+	case "debug":
+		config.Debug, err = parseBool(value)
+	case "jobs":
+		config.Jobs, err = parseInt(value)
+	case "local-format":
+		if value != "" && contains(LocalFormats, value) == false {
+			return errors.New(
+				"Format should be one of \"" + strings.Join(LocalFormats, "\", \"") + "\"",
+			)
+		}
+
+		config.LocalFormat = value
+	case "project-roots":
+		config.ProjectRoots = value
+	case "proxy-place":
+		config.ProxyPlace = value
+	case "read-timeout":
+		config.ReadTimeout, err = parseDuration(value)
+	case "restart-shell":
+		config.RestartShell, err = parseBool(value)
+	case "retries":
+		config.Retries = nil
+
+		if value != "" {
+			retries, err := parseInt(value)
+			if err != nil {
+				return err
+			}
+
+			config.Retries = &retries
+		}
+	case "system-root":
+		config.SystemRoot = value
+	case "with-modules":
+		config.WithModules, err = parseBool(value)
+	case "without-spinner":
+		config.WithoutSpinner, err = parseBool(value)
+	default:
+		err = unknown(key)
+	}
+
+	return
+}
+
+// List gets all defined settings as key and value pairs
+func (config *Config) List() (result [][]string) {
+	defaults := &Config{}
+
+	for _, key := range Keys {
+		value, _ := config.Get(key)
+		fallback, _ := defaults.Get(key)
+
+		if value == fallback {
+			continue
+		}
+
+		result = append(result, []string{key, value})
+	}
+
+	names := []string{}
+	for name := range config.Mirrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, field := range MirrorFields {
+			value, _ := config.Mirrors[name].get(field)
+
+			if value != "" {
+				result = append(result, []string{"mirrors." + name + "." + field, value})
+			}
+		}
+	}
+
+	return
+}
+
+// setMirror sets field of the mirror, creating the latter if needed
+func (config *Config) setMirror(name, field, value string) error {
+	if config.Mirrors == nil {
+		config.Mirrors = map[string]*Mirror{}
+	}
+
+	mirror, ok := config.Mirrors[name]
+	if ok == false {
+		mirror = &Mirror{}
+	}
+
+	switch field {
+	case "url":
+		mirror.URL = value
+	case "list":
+		mirror.List = value
+	case "username":
+		mirror.Username = value
+	case "password":
+		mirror.Password = value
+	case "token":
+		mirror.Token = value
+	}
+
+	if *mirror == (Mirror{}) {
+		delete(config.Mirrors, name)
+		return nil
+	}
+
+	config.Mirrors[name] = mirror
+
+	return nil
+}
+
+// get gets field of the mirror
+func (mirror *Mirror) get(field string) (string, error) {
+	if mirror == nil {
+		mirror = &Mirror{}
+	}
+
+	switch field {
+	case "url":
+		return mirror.URL, nil
+	case "list":
+		return mirror.List, nil
+	case "username":
+		return mirror.Username, nil
+	case "password":
+		return mirror.Password, nil
+	case "token":
+		return mirror.Token, nil
+	}
+
+	return "", unknown("mirrors.<name>." + field)
+}
+
+// Download returns mirror link for the distributions or the fallback
+func (mirror *Mirror) Download(fallback string) string {
+	if mirror == nil || mirror.URL == "" {
+		return fallback
+	}
+
+	return mirror.URL
+}
+
+// Listing returns mirror link for the list of the versions or the fallback
+func (mirror *Mirror) Listing(fallback string) string {
+	if mirror == nil || mirror.List == "" {
+		return fallback
+	}
+
+	return mirror.List
+}
+
+// HasCredentials checks if mirror requires authentication
+func (mirror *Mirror) HasCredentials() bool {
+	if mirror == nil {
+		return false
+	}
+
+	return mirror.Token != "" || mirror.Username != ""
+}
+
+// mirrorKey splits "mirrors.<name>.<field>" key
+func mirrorKey(key string) (name, field string, ok bool) {
+	parts := strings.Split(key, ".")
+
+	if len(parts) != 3 || parts[0] != "mirrors" || parts[1] == "" {
+		return
+	}
+
+	if contains(MirrorFields, parts[2]) == false {
+		return
+	}
+
+	return parts[1], parts[2], true
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("\"" + value + "\" should be either \"true\" or \"false\"")
+	}
+
+	return result, nil
+}
+
+func parseDuration(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	_, err := time.ParseDuration(value)
+	if err != nil {
+		return "", errors.New("\"" + value + "\" is not a duration, use values like \"10m\" or \"1h\"")
+	}
+
+	return value, nil
+}
+
+func parseInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil || result < 0 {
+		return 0, errors.New("\"" + value + "\" should be a positive number")
+	}
+
+	return result, nil
+}
+
+func unknown(key string) error {
+	return errors.New("Unknown setting \"" + key + "\"")
+}
+
+func contains(list []string, value string) bool {
+	for _, elem := range list {
+		if elem == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/config"
+)
+
+var _ = Describe("config", func() {
+	var (
+		path, _ = filepath.Abs("../testdata/config")
+	)
+
+	Describe("Load", func() {
+		It("should not return an error if file doesn't exist", func() {
+			config, err := Load(filepath.Join(path, "not-existent.toml"))
+
+			Expect(err).To(BeNil())
+			Expect(config.Mirrors).To(BeEmpty())
+		})
+
+		It("should return an error for malformed file", func() {
+			_, err := Load(filepath.Join(path, "malformed.toml"))
+
+			Expect(err.Error()).To(ContainSubstring("malformed.toml\" is malformed"))
+		})
+
+		It("should get mirrors", func() {
+			config, err := Load(filepath.Join(path, "config.toml"))
+
+			Expect(err).To(BeNil())
+
+			Expect(config.Mirrors["node"].URL).To(Equal("https://artifactory.example.com/nodejs/dist"))
+			Expect(config.Mirrors["node"].Token).To(Equal("secret"))
+
+			Expect(config.Mirrors["go"].List).To(Equal("https://artifactory.example.com/golang/dl"))
+			Expect(config.Mirrors["go"].Username).To(Equal("user"))
+			Expect(config.Mirrors["go"].Password).To(Equal("pass"))
+		})
+	})
+
+	Describe("Mirror", func() {
+		It("should use fallbacks for undefined mirror", func() {
+			var mirror *Mirror
+
+			Expect(mirror.Download("download")).To(Equal("download"))
+			Expect(mirror.Listing("list")).To(Equal("list"))
+			Expect(mirror.HasCredentials()).To(Equal(false))
+		})
+
+		It("should use fallback for the list only if it's not defined", func() {
+			mirror := &Mirror{URL: "https://mirror"}
+
+			Expect(mirror.Download("download")).To(Equal("https://mirror"))
+			Expect(mirror.Listing("list")).To(Equal("list"))
+		})
+
+		It("should have credentials", func() {
+			Expect((&Mirror{Token: "test"}).HasCredentials()).To(Equal(true))
+			Expect((&Mirror{Username: "test"}).HasCredentials()).To(Equal(true))
+		})
+	})
+	Describe("Get", func() {
+		It("should get the setting", func() {
+			config, _ := Load(filepath.Join(path, "config.toml"))
+
+			value, err := config.Get("mirrors.go.username")
+
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("user"))
+		})
+
+		It("should get the default value", func() {
+			value, err := (&Config{}).Get("with-modules")
+
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("false"))
+		})
+
+		It("should return an error for unknown setting", func() {
+			_, err := (&Config{}).Get("test")
+
+			Expect(err).Should(MatchError("Unknown setting \"test\""))
+		})
+	})
+
+	Describe("Set", func() {
+		var config *Config
+
+		BeforeEach(func() {
+			config = &Config{}
+		})
+
+		It("should set the settings", func() {
+			Expect(config.Set("cache-ttl", "1h")).To(BeNil())
+			Expect(config.Set("jobs", "2")).To(BeNil())
+			Expect(config.Set("with-modules", "true")).To(BeNil())
+			Expect(config.Set("local-format", "tool-versions")).To(BeNil())
+			Expect(config.Set("read-timeout", "2m")).To(BeNil())
+			Expect(config.Set("retries", "0")).To(BeNil())
+			Expect(config.Set("project-roots", "~/work:~/oss")).To(BeNil())
+			Expect(config.Set("mirrors.node.url", "https://mirror")).To(BeNil())
+
+			Expect(config.CacheTTL).To(Equal("1h"))
+			Expect(config.Jobs).To(Equal(2))
+			Expect(config.WithModules).To(Equal(true))
+			Expect(config.LocalFormat).To(Equal("tool-versions"))
+			Expect(config.ReadTimeout).To(Equal("2m"))
+			Expect(*config.Retries).To(Equal(0))
+			Expect(config.ProjectRoots).To(Equal("~/work:~/oss"))
+			Expect(config.Mirrors["node"].URL).To(Equal("https://mirror"))
+		})
+
+		It("should reset the settings", func() {
+			config.Set("jobs", "2")
+			config.Set("mirrors.node.url", "https://mirror")
+
+			config.Set("jobs", "")
+			config.Set("mirrors.node.url", "")
+
+			Expect(config.Jobs).To(Equal(0))
+			Expect(config.Mirrors).To(BeEmpty())
+		})
+
+		It("should validate the values", func() {
+			Expect(config.Set("cache-ttl", "test")).NotTo(BeNil())
+			Expect(config.Set("connect-timeout", "30")).NotTo(BeNil())
+			Expect(config.Set("retries", "test")).NotTo(BeNil())
+			Expect(config.Set("jobs", "-1")).NotTo(BeNil())
+			Expect(config.Set("debug", "test")).NotTo(BeNil())
+			Expect(config.Set("local-format", "test")).NotTo(BeNil())
+			Expect(config.Set("mirrors.node.test", "test")).NotTo(BeNil())
+		})
+	})
+
+	Describe("List", func() {
+		It("should list only defined settings", func() {
+			config := &Config{Jobs: 2}
+			config.Set("retries", "0")
+			config.Set("mirrors.node.token", "secret")
+
+			Expect(config.List()).To(Equal([][]string{
+				{"jobs", "2"},
+				{"retries", "0"},
+				{"mirrors.node.token", "secret"},
+			}))
+		})
+	})
+
+	Describe("Save", func() {
+		It("should save the settings", func() {
+			dir, _ := ioutil.TempDir("", "eclectica-config")
+			defer os.RemoveAll(dir)
+
+			file := filepath.Join(dir, "config.toml")
+			config := &Config{Jobs: 2}
+			config.Set("mirrors.node.url", "https://mirror")
+
+			Expect(Save(file, config)).To(BeNil())
+
+			saved, err := Load(file)
+
+			Expect(err).To(BeNil())
+			Expect(saved.Jobs).To(Equal(2))
+			Expect(saved.Mirrors["node"].URL).To(Equal("https://mirror"))
+		})
+	})
+})
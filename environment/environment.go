@@ -0,0 +1,233 @@
+// Package environment composes environment of the language versions,
+// the same one ec-proxy provides for the executed binaries
+package environment
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+// Version is the installed language version
+type Version struct {
+	Language string
+	Version  string
+}
+
+// Snapshot has values of the environment variables before they were changed,
+// nil value means variable wasn't defined
+type Snapshot map[string]*string
+
+// Local gets versions defined for the provided (or current) path,
+// languages without defined or installed versions are skipped
+func Local(args ...interface{}) (result []Version) {
+	for _, language := range plugins.Plugins {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		version, err := plugin.ResolveVersion(args...)
+		if err != nil || version == "current" || version == versions.System {
+			continue
+		}
+
+		result = append(result, Version{language, version})
+	}
+
+	return
+}
+
+// Current gets versions used for the provided (or current) path, the ones defined
+// there or the current versions of the languages, if they were installed
+func Current(args ...interface{}) (result []Version, err error) {
+	for _, language := range plugins.Plugins {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		version, err := plugin.ResolveVersion(args...)
+		if err != nil {
+			return nil, err
+		}
+
+		if version == "current" {
+			version = variables.CurrentVersion(language)
+		}
+
+		// System versions are already in the environment
+		if version == "" || version == versions.System {
+			continue
+		}
+
+		result = append(result, Version{language, version})
+	}
+
+	return
+}
+
+// Resolve gets installed version of the language, masks are resolved to the latest installed version
+func Resolve(language, version string) (result Version, err error) {
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+	})
+
+	if versions.IsPartial(version) {
+		mask := version
+
+		version, err = versions.Latest(mask, plugin.List())
+		if err != nil {
+			return result, errors.New("None of the \"" + mask + "\" versions of " + language + " are installed")
+		}
+	}
+
+	if variables.IsInstalled(language, version) == false {
+		return result, errors.New("Version \"" + version + "\" of " + language + " is not installed")
+	}
+
+	return Version{language, version}, nil
+}
+
+// Override replaces versions of the same languages or adds them to the list
+func Override(vers []Version, overrides ...Version) []Version {
+	result := append([]Version{}, vers...)
+
+	for _, override := range overrides {
+		replaced := false
+
+		for i, version := range result {
+			if version.Language == override.Language {
+				result[i] = override
+				replaced = true
+			}
+		}
+
+		if replaced == false {
+			result = append(result, override)
+		}
+	}
+
+	return result
+}
+
+// Bins gets bin folders of the versions
+func Bins(vers []Version) (result []string) {
+	for _, version := range vers {
+		if version.Version == versions.System {
+			continue
+		}
+
+		result = append(result, filepath.Join(variables.Path(version.Language, version.Version), "bin"))
+	}
+
+	return
+}
+
+// Variables gets environment variables of the versions as the key and value map
+func Variables(vers []Version) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, version := range vers {
+		if version.Version == versions.System {
+			continue
+		}
+
+		environment, err := plugins.New(&plugins.Args{
+			Language: version.Language,
+			Version:  version.Version,
+		}).Environment()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variable := range environment {
+			parts := strings.SplitN(variable, "=", 2)
+
+			if len(parts) == 2 {
+				result[parts[0]] = parts[1]
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Keys gets sorted keys of the variables
+func Keys(vars map[string]string) (result []string) {
+	for key := range vars {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return
+}
+
+// Save remembers current values of the provided environment variables
+func Save(keys []string) Snapshot {
+	snapshot := Snapshot{}
+
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			snapshot[key] = &value
+		} else {
+			snapshot[key] = nil
+		}
+	}
+
+	return snapshot
+}
+
+// Decode gets snapshot from the encoded value, malformed value is considered empty
+func Decode(value string) Snapshot {
+	snapshot := Snapshot{}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return snapshot
+	}
+
+	json.Unmarshal(data, &snapshot)
+
+	return snapshot
+}
+
+// Encode gets snapshot as a value safe for the environment variable
+func (snapshot Snapshot) Encode() (string, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Restore sets the remembered values for the current process
+func (snapshot Snapshot) Restore() {
+	for key, value := range snapshot {
+		if value == nil {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, *value)
+		}
+	}
+}
+
+// Keys gets sorted names of the remembered variables
+func (snapshot Snapshot) Keys() (result []string) {
+	for key := range snapshot {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return
+}
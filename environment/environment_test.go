@@ -0,0 +1,155 @@
+package environment_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/environment"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("environment", func() {
+	var (
+		home    string
+		project string
+	)
+
+	BeforeEach(func() {
+		home = filepath.Join(os.TempDir(), "eclectica-environment-home")
+		project = filepath.Join(os.TempDir(), "eclectica-environment-project")
+
+		os.Setenv("EC_HOME", home)
+		os.MkdirAll(project, 0755)
+
+		os.MkdirAll(variables.Path("go", "1.9.0"), 0755)
+		variables.WriteVersion("go", "1.9.0")
+
+		io.WriteFile(filepath.Join(project, ".go-version"), "1.9.0")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_HOME")
+		os.RemoveAll(home)
+		os.RemoveAll(project)
+	})
+
+	Describe("Local", func() {
+		It("should get versions defined for the path", func() {
+			Expect(Local(project)).To(Equal([]Version{{"go", "1.9.0"}}))
+		})
+
+		It("should skip versions which are not installed", func() {
+			io.WriteFile(filepath.Join(project, ".node-version"), "6.0.0")
+
+			Expect(Local(project)).To(HaveLen(1))
+		})
+	})
+
+	Describe("Current", func() {
+		It("should fall back to the current versions", func() {
+			os.MkdirAll(variables.Path("node", "6.0.0"), 0755)
+			variables.WriteVersion("node", "6.0.0")
+			os.MkdirAll(filepath.Dir(variables.Path("node")), 0755)
+			os.Symlink(variables.Path("node", "6.0.0"), variables.Path("node"))
+
+			vers, err := Current(project)
+
+			Expect(err).To(BeNil())
+			Expect(vers).To(Equal([]Version{{"node", "6.0.0"}, {"go", "1.9.0"}}))
+		})
+
+		It("should return an error if defined version is not installed", func() {
+			io.WriteFile(filepath.Join(project, ".node-version"), "6.0.0")
+
+			_, err := Current(project)
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should resolve the mask", func() {
+			version, err := Resolve("go", "1.9")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal(Version{"go", "1.9.0"}))
+		})
+
+		It("should return an error if version is not installed", func() {
+			_, err := Resolve("go", "1.10.0")
+
+			Expect(err.Error()).To(Equal(`Version "1.10.0" of go is not installed`))
+		})
+	})
+
+	Describe("Override", func() {
+		It("should replace and add versions", func() {
+			vers := Override(
+				[]Version{{"go", "1.9.0"}},
+				Version{"go", "1.10.0"}, Version{"node", "8.0.0"},
+			)
+
+			Expect(vers).To(Equal([]Version{{"go", "1.10.0"}, {"node", "8.0.0"}}))
+		})
+	})
+
+	Describe("Bins", func() {
+		It("should get bin folders", func() {
+			bins := Bins([]Version{{"go", "1.9.0"}})
+
+			Expect(bins).To(Equal([]string{filepath.Join(home, "versions/go/1.9.0/bin")}))
+		})
+	})
+
+	Describe("Variables", func() {
+		It("should get environment of the plugins", func() {
+			vars, err := Variables([]Version{{"go", "1.9.0"}})
+
+			Expect(err).To(BeNil())
+			Expect(vars["GOROOT"]).To(Equal(filepath.Join(home, "versions/go/1.9.0")))
+		})
+	})
+
+	Describe("Snapshot", func() {
+		AfterEach(func() {
+			os.Unsetenv("EC_TEST_DEFINED")
+			os.Unsetenv("EC_TEST_UNDEFINED")
+		})
+
+		It("should restore the saved values", func() {
+			os.Setenv("EC_TEST_DEFINED", "1")
+
+			snapshot := Save([]string{"EC_TEST_DEFINED", "EC_TEST_UNDEFINED"})
+
+			os.Setenv("EC_TEST_DEFINED", "2")
+			os.Setenv("EC_TEST_UNDEFINED", "2")
+
+			snapshot.Restore()
+
+			_, ok := os.LookupEnv("EC_TEST_UNDEFINED")
+
+			Expect(os.Getenv("EC_TEST_DEFINED")).To(Equal("1"))
+			Expect(ok).To(Equal(false))
+		})
+
+		It("should be encoded and decoded", func() {
+			os.Setenv("EC_TEST_DEFINED", "it's")
+
+			encoded, err := Save([]string{"EC_TEST_DEFINED", "EC_TEST_UNDEFINED"}).Encode()
+			snapshot := Decode(encoded)
+
+			Expect(err).To(BeNil())
+			Expect(snapshot.Keys()).To(Equal([]string{"EC_TEST_DEFINED", "EC_TEST_UNDEFINED"}))
+			Expect(*snapshot["EC_TEST_DEFINED"]).To(Equal("it's"))
+			Expect(snapshot["EC_TEST_UNDEFINED"]).To(BeNil())
+		})
+
+		It("should consider malformed value empty", func() {
+			Expect(Decode("not encoded")).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,41 @@
+module github.com/markelog/eclectica
+
+go 1.18
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/PuerkitoBio/goquery v1.8.1
+	github.com/bouk/monkey v1.0.1
+	github.com/chuckpreslar/emission v0.0.0-20170206194824-a7ddd980baf9
+	github.com/dustin/go-humanize v1.0.1
+	github.com/go-errors/errors v1.5.1
+	github.com/jarcoal/httpmock v1.4.2
+	github.com/kardianos/osext v0.0.0-20190222173326-2bc1f35cddc0
+	github.com/kr/pty v1.1.8
+	github.com/mgutz/ansi v0.0.0-20200706080929-d51e80ef957d
+	github.com/onsi/ginkgo v1.16.5
+	github.com/onsi/gomega v1.27.10
+	github.com/sanity-io/litter v1.5.8
+	github.com/schollz/closestmatch v2.1.0+incompatible
+	github.com/spf13/cobra v1.8.1
+	github.com/tj/go-spin v1.1.0
+	golang.org/x/crypto v0.24.0
+)
+
+require (
+	github.com/andybalholm/cascadia v1.3.1 // indirect
+	github.com/creack/pty v1.1.7 // indirect
+	github.com/fsnotify/fsnotify v1.4.9 // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/mattn/go-colorable v0.1.15 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/nxadm/tail v1.4.8 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/term v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -7,12 +7,27 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-errors/errors"
 )
 
+var (
+
+	// Permissions for the created folders and files,
+	// see SetPermissions() for the shared installations
+	perm os.FileMode = 0700
+)
+
 const (
-	perm = 0700
+
+	// ToolVersions is the name of the file which can define versions
+	// for several languages at once, one "<language> <version>" pair per line
+	ToolVersions = ".tool-versions"
+
+	// Permissions for the created dot files, these are project files,
+	// so unlike eclectica files they are not executable
+	dotPerm os.FileMode = 0644
 )
 
 // Walker signature function
@@ -62,6 +77,89 @@ func GetVersion(args ...interface{}) (version, path string, err error) {
 		return current, "", nil
 	}
 
+	version, err = readVersion(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	if version == "" {
+		return current, "", nil
+	}
+
+	return version, path, nil
+}
+
+// FindVersion works like GetVersion, but on every level of the filesystem tree
+// it also looks inside of the multi-language file, where language
+// could be defined under any of the provided names
+func FindVersion(names, dots []string, args ...interface{}) (version, versionPath string, err error) {
+	var path string
+
+	if len(args) > 0 {
+		path = args[0].(string)
+	} else {
+
+		path, err = os.Getwd()
+		if err != nil {
+			err = errors.New(err)
+			return
+		}
+	}
+
+	walkUp(path, func(path string) bool {
+		for _, file := range dots {
+			p := filepath.Join(path, file)
+
+			if _, errStat := os.Stat(p); errStat != nil {
+				continue
+			}
+
+			version, err = readVersion(p)
+			versionPath = p
+
+			return true
+		}
+
+		p := filepath.Join(path, ToolVersions)
+		if _, errStat := os.Stat(p); errStat != nil {
+			return false
+		}
+
+		tools, errRead := ReadToolVersions(p)
+		if errRead != nil {
+			err = errRead
+			return true
+		}
+
+		for _, name := range names {
+			if value, ok := tools[name]; ok {
+				version = value
+				versionPath = p
+
+				return true
+			}
+		}
+
+		return false
+	})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if version == "" {
+		return "current", "", nil
+	}
+
+	return
+}
+
+// ReadToolVersions reads the multi-language file and returns map of language
+// names to their versions, comments and empty lines are ignored.
+// If there is more then one version defined for the language, first one is used
+func ReadToolVersions(path string) (result map[string]string, err error) {
+	result = map[string]string{}
+
 	file, err := os.Open(path)
 	if err != nil {
 		err = errors.New(err)
@@ -72,14 +170,159 @@ func GetVersion(args ...interface{}) (version, path string, err error) {
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		return scanner.Text(), path, nil
+		line := scanner.Text()
+
+		if index := strings.Index(line, "#"); index > -1 {
+			line = line[:index]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		if _, ok := result[fields[0]]; ok {
+			continue
+		}
+
+		result[fields[0]] = fields[1]
 	}
 
 	if scannerErr := scanner.Err(); scannerErr != nil {
-		return "", "", scannerErr
+		return nil, errors.New(scannerErr)
 	}
 
-	return current, "", nil
+	return
+}
+
+// WriteToolVersion sets version of the language in the multi-language file,
+// line of the language is replaced and other lines are preserved, if language
+// is not defined yet, it is added with the last of the provided names
+func WriteToolVersion(path string, names []string, version string) error {
+	var (
+		mode     = dotPerm
+		lines    = []string{}
+		replaced = false
+	)
+
+	if stat, err := os.Stat(path); err == nil {
+		mode = stat.Mode()
+		content := strings.TrimRight(Read(path), "\n")
+
+		if content != "" {
+			lines = strings.Split(content, "\n")
+		}
+	}
+
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if replaced || len(fields) == 0 || isOneOf(fields[0], names) == false {
+			continue
+		}
+
+		comment := ""
+		if index := strings.Index(line, "#"); index > -1 {
+			comment = " " + line[index:]
+		}
+
+		lines[i] = fields[0] + " " + version + comment
+		replaced = true
+	}
+
+	if replaced == false {
+		lines = append(lines, names[len(names)-1]+" "+version)
+	}
+
+	err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), mode)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteDotFile writes version to the dot file, multi-language file is updated
+// with WriteToolVersion, in other files only the first line is replaced
+func WriteDotFile(path string, names []string, version string) error {
+	if filepath.Base(path) == ToolVersions {
+		return WriteToolVersion(path, names, version)
+	}
+
+	var (
+		mode  = dotPerm
+		lines = []string{version}
+	)
+
+	if stat, err := os.Stat(path); err == nil {
+		mode = stat.Mode()
+		content := strings.Split(strings.TrimRight(Read(path), "\n"), "\n")
+		lines = append(lines, content[1:]...)
+	}
+
+	err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")), mode)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, elem := range names {
+		if elem == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RewriteSymlinks replaces "from" prefix of the absolute symlinks
+// located in the "root" folder with the "to" one
+func RewriteSymlinks(root, from, to string) error {
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return err
+		}
+
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+
+		if target != from && strings.HasPrefix(target, from+"/") == false {
+			return nil
+		}
+
+		return Symlink(path, to+strings.TrimPrefix(target, from))
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// readVersion reads the first line of the dot file
+func readVersion(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		return scanner.Text(), nil
+	}
+
+	if scannerErr := scanner.Err(); scannerErr != nil {
+		return "", scannerErr
+	}
+
+	return "", nil
 }
 
 // FindDotFile finds file up in the filesystem tree
@@ -115,6 +358,12 @@ func FindDotFile(args ...interface{}) (versionPath string, err error) {
 	return
 }
 
+// SetPermissions sets permissions for all created folders and files,
+// useful when they should be accessible for other users
+func SetPermissions(mode os.FileMode) {
+	perm = mode
+}
+
 // CreateDir creates dir with predefined perms
 func CreateDir(path string) (string, error) {
 	err := os.MkdirAll(path, perm)
@@ -186,3 +435,29 @@ func Symlink(current, base string) (err error) {
 
 	return nil
 }
+
+// Size gets size of the file or all files in the folder,
+// symlinks are not followed, nonexistent path has zero size
+func Size(path string) (size int64, err error) {
+	err = filepath.Walk(path, func(path string, info os.FileInfo, errWalk error) error {
+		if os.IsNotExist(errWalk) {
+			return nil
+		}
+
+		if errWalk != nil {
+			return errWalk
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	return
+}
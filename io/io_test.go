@@ -1,6 +1,8 @@
 package io_test
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -36,4 +38,255 @@ var _ = Describe("io", func() {
 			Expect(result).To(Equal("6.8.0"))
 		})
 	})
+
+	Describe("WriteToolVersion", func() {
+		var (
+			dir  string
+			path string
+		)
+
+		BeforeEach(func() {
+			dir, _ = ioutil.TempDir("", "eclectica-io")
+			path = filepath.Join(dir, ToolVersions)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should create the file", func() {
+			err := WriteToolVersion(path, []string{"node", "nodejs"}, "8.9.4")
+
+			Expect(err).To(BeNil())
+			Expect(Read(path)).To(Equal("nodejs 8.9.4\n"))
+		})
+
+		It("should replace version and preserve other lines", func() {
+			ioutil.WriteFile(path, []byte("# comment\nnode 6.0.0\npython 3.6.0\n"), 0644)
+
+			err := WriteToolVersion(path, []string{"node", "nodejs"}, "8.9.4")
+
+			Expect(err).To(BeNil())
+			Expect(Read(path)).To(Equal("# comment\nnode 8.9.4\npython 3.6.0\n"))
+		})
+
+		It("should add the language", func() {
+			ioutil.WriteFile(path, []byte("python 3.6.0"), 0644)
+
+			WriteToolVersion(path, []string{"go", "golang"}, "1.9.2")
+
+			Expect(Read(path)).To(Equal("python 3.6.0\ngolang 1.9.2\n"))
+		})
+	})
+
+	Describe("WriteDotFile", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir, _ = ioutil.TempDir("", "eclectica-io")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should create the file", func() {
+			path := filepath.Join(dir, ".node-version")
+
+			err := WriteDotFile(path, []string{"node", "nodejs"}, "8.9.4")
+
+			Expect(err).To(BeNil())
+			Expect(Read(path)).To(Equal("8.9.4"))
+		})
+
+		It("should not create executable files", func() {
+			SetPermissions(0755)
+			defer SetPermissions(0700)
+
+			nvmrc := filepath.Join(dir, ".nvmrc")
+			tools := filepath.Join(dir, ToolVersions)
+
+			WriteDotFile(nvmrc, []string{"node", "nodejs"}, "8.9.4")
+			WriteDotFile(tools, []string{"node", "nodejs"}, "8.9.4")
+
+			for _, path := range []string{nvmrc, tools} {
+				stat, _ := os.Stat(path)
+				Expect(stat.Mode().Perm()).To(Equal(os.FileMode(0644)))
+			}
+		})
+
+		It("should keep mode of the existing file", func() {
+			path := filepath.Join(dir, ".nvmrc")
+			ioutil.WriteFile(path, []byte("6.0.0"), 0600)
+
+			WriteDotFile(path, []string{"node", "nodejs"}, "8.9.4")
+
+			stat, _ := os.Stat(path)
+			Expect(stat.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("should only replace the first line", func() {
+			path := filepath.Join(dir, ".nvmrc")
+			ioutil.WriteFile(path, []byte("6.0.0\n# lts\n"), 0644)
+
+			WriteDotFile(path, []string{"node", "nodejs"}, "8.9.4")
+
+			Expect(Read(path)).To(Equal("8.9.4\n# lts"))
+		})
+
+		It("should update the multi-language file", func() {
+			path := filepath.Join(dir, ToolVersions)
+			ioutil.WriteFile(path, []byte("nodejs 6.0.0 # lts\npython 3.6.0\n"), 0644)
+
+			WriteDotFile(path, []string{"node", "nodejs"}, "8.9.4")
+
+			Expect(Read(path)).To(Equal("nodejs 8.9.4 # lts\npython 3.6.0\n"))
+		})
+	})
+
+	Describe("SetPermissions", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir, _ = ioutil.TempDir("", "eclectica-io")
+		})
+
+		AfterEach(func() {
+			SetPermissions(0700)
+			os.RemoveAll(dir)
+		})
+
+		It("should create folders with provided permissions", func() {
+			path := filepath.Join(dir, "shared")
+
+			SetPermissions(0755)
+			CreateDir(path)
+
+			stat, _ := os.Stat(path)
+			Expect(stat.Mode().Perm()).To(Equal(os.FileMode(0755)))
+		})
+	})
+
+	Describe("RewriteSymlinks", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir, _ = ioutil.TempDir("", "eclectica-io")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should rewrite only absolute symlinks with the prefix", func() {
+			var (
+				to       = filepath.Join(dir, "to")
+				current  = filepath.Join(to, "node", "current")
+				relative = filepath.Join(to, "node", "relative")
+				other    = filepath.Join(to, "node", "other")
+			)
+
+			CreateDir(filepath.Join(to, "node", "8.9.4"))
+			os.Symlink("/from/node/8.9.4", current)
+			os.Symlink("8.9.4", relative)
+			os.Symlink("/fromage/node/8.9.4", other)
+
+			err := RewriteSymlinks(to, "/from", to)
+			Expect(err).To(BeNil())
+
+			target, _ := os.Readlink(current)
+			Expect(target).To(Equal(filepath.Join(to, "node", "8.9.4")))
+
+			target, _ = os.Readlink(relative)
+			Expect(target).To(Equal("8.9.4"))
+
+			target, _ = os.Readlink(other)
+			Expect(target).To(Equal("/fromage/node/8.9.4"))
+		})
+	})
+
+	Describe("Size", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir, _ = ioutil.TempDir("", "eclectica-io")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should sum sizes of the files without following symlinks", func() {
+			CreateDir(filepath.Join(dir, "node", "8.9.4", "bin"))
+			WriteFile(filepath.Join(dir, "node", "8.9.4", "bin", "node"), "12345")
+			WriteFile(filepath.Join(dir, "node", "8.9.4", ".eclectica"), "8.9.4")
+			os.Symlink(filepath.Join(dir, "node", "8.9.4"), filepath.Join(dir, "node", "current"))
+
+			size, err := Size(filepath.Join(dir, "node"))
+
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(10)))
+		})
+
+		It("should get size of the file", func() {
+			WriteFile(filepath.Join(dir, "archive"), "123")
+
+			size, err := Size(filepath.Join(dir, "archive"))
+
+			Expect(err).To(BeNil())
+			Expect(size).To(Equal(int64(3)))
+		})
+
+		It("should return zero for nonexistent path", func() {
+			size, err := Size(filepath.Join(dir, "nope"))
+
+			Expect(err).To(BeNil())
+			Expect(size).To(BeZero())
+		})
+	})
+
+	Describe("ReadToolVersions", func() {
+		It("should read versions for all languages and skip comments", func() {
+			path, _ := filepath.Abs("../testdata/io/tool-versions/.tool-versions")
+			result, err := ReadToolVersions(path)
+
+			Expect(err).To(BeNil())
+			Expect(len(result)).To(Equal(2))
+			Expect(result["nodejs"]).To(Equal("8.9.4"))
+			Expect(result["python"]).To(Equal("3.6.0"))
+		})
+	})
+
+	Describe("FindVersion", func() {
+		It("should get version from multi-language file by alternative name", func() {
+			path, _ := filepath.Abs("../testdata/io/tool-versions/")
+			result, dotPath, _ := FindVersion([]string{"node", "nodejs"}, []string{".nvmrc"}, path)
+
+			Expect(dotPath).To(ContainSubstring("io/tool-versions/.tool-versions"))
+			Expect(result).To(Equal("8.9.4"))
+		})
+
+		It("should prefer closest language specific dot file", func() {
+			path, _ := filepath.Abs("../testdata/io/tool-versions/nested")
+			result, dotPath, _ := FindVersion([]string{"python"}, []string{".python-version"}, path)
+
+			Expect(dotPath).To(ContainSubstring("tool-versions/nested/.python-version"))
+			Expect(result).To(Equal("2.7.14"))
+		})
+
+		It("should look in multi-language file up in the tree", func() {
+			path, _ := filepath.Abs("../testdata/io/tool-versions/nested")
+			result, _, _ := FindVersion([]string{"node", "nodejs"}, []string{".nvmrc"}, path)
+
+			Expect(result).To(Equal("8.9.4"))
+		})
+
+		It("should return \"current\" if nothing was defined", func() {
+			path, _ := filepath.Abs("../testdata/io/tool-versions/")
+			result, dotPath, _ := FindVersion([]string{"elm"}, []string{".elm-version"}, path)
+
+			Expect(dotPath).To(Equal(""))
+			Expect(result).To(Equal("current"))
+		})
+	})
 })
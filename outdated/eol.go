@@ -0,0 +1,200 @@
+package outdated
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+// Dates are end-of-life dates of the release lines,
+// like "8" or "0.10" for node.js and "3.6" for python
+type Dates map[string]time.Time
+
+var (
+	// Sources are the upstream release metadata with end-of-life dates
+	Sources = map[string]string{
+		"node":   "https://raw.githubusercontent.com/nodejs/Release/main/schedule.json",
+		"python": "https://raw.githubusercontent.com/python/devguide/main/include/release-cycle.json",
+		"ruby":   "https://raw.githubusercontent.com/ruby/www.ruby-lang.org/master/_data/branches.yml",
+	}
+
+	parsers = map[string]func(string) (Dates, error){
+		"node":   parseNode,
+		"python": parsePython,
+		"ruby":   parseRuby,
+	}
+
+	rName = regexp.MustCompile(`^-?\s*name:\s*['"]?([\d.]+)`)
+	rEOL  = regexp.MustCompile(`^\s*(expected_)?eol_date:\s*['"]?([\d-]+)`)
+)
+
+// EOL gets end-of-life dates of the language release lines, metadata is cached
+// for the time defined by the variables.CacheTTL(), languages without it have no dates
+func EOL(language string) (Dates, error) {
+	link, ok := Sources[language]
+	if ok == false {
+		return Dates{}, nil
+	}
+
+	var (
+		parse = parsers[language]
+		path  = filepath.Join(variables.Cache(), "eol-"+language)
+		ttl   = variables.CacheTTL()
+	)
+
+	if stat, err := os.Stat(path); err == nil && time.Since(stat.ModTime()) < ttl {
+		if dates, errParse := parse(io.Read(path)); errParse == nil && len(dates) > 0 {
+			return dates, nil
+		}
+	}
+
+	body, err := request.Body(link)
+	if err != nil {
+		return nil, err
+	}
+
+	dates, err := parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache is not essential, so ignore the errors
+	if ttl > 0 {
+		io.CreateDir(variables.Cache())
+		io.WriteFile(path, body)
+	}
+
+	return dates, nil
+}
+
+// Find gets end-of-life date of the version line, zero if it's unknown
+func (dates Dates) Find(version string) time.Time {
+	parsed, err := versions.Parse(version)
+	if err != nil {
+		return time.Time{}
+	}
+
+	if date, ok := dates[versions.Line(version)]; ok {
+		return date
+	}
+
+	if date, ok := dates[strconv.Itoa(parsed.Major)]; ok {
+		return date
+	}
+
+	return time.Time{}
+}
+
+// parseNode parses schedule of the node.js releases
+func parseNode(content string) (Dates, error) {
+	schedule := map[string]struct {
+		End string `json:"end"`
+	}{}
+
+	err := json.Unmarshal([]byte(content), &schedule)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	dates := Dates{}
+	for line, info := range schedule {
+		if date, ok := parseDate(info.End); ok {
+			dates[strings.TrimPrefix(line, "v")] = date
+		}
+	}
+
+	return dates, nil
+}
+
+// parsePython parses release cycle of the python branches
+func parsePython(content string) (Dates, error) {
+	cycle := map[string]struct {
+		EOL string `json:"end_of_life"`
+	}{}
+
+	err := json.Unmarshal([]byte(content), &cycle)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	dates := Dates{}
+	for line, info := range cycle {
+		if date, ok := parseDate(info.EOL); ok {
+			dates[line] = date
+		}
+	}
+
+	return dates, nil
+}
+
+// parseRuby parses list of the ruby branches, which is a YAML file,
+// but only names and end-of-life dates are needed from it
+func parseRuby(content string) (Dates, error) {
+	var (
+		dates    = Dates{}
+		expected = Dates{}
+		name     string
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if matches := rName.FindStringSubmatch(line); matches != nil {
+			name = matches[1]
+			continue
+		}
+
+		matches := rEOL.FindStringSubmatch(line)
+		if matches == nil || name == "" {
+			continue
+		}
+
+		date, ok := parseDate(matches[2])
+		if ok == false {
+			continue
+		}
+
+		if matches[1] == "" {
+			dates[name] = date
+		} else {
+			expected[name] = date
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	// Actual date is more important then the expected one
+	for name, date := range expected {
+		if _, ok := dates[name]; ok == false {
+			dates[name] = date
+		}
+	}
+
+	return dates, nil
+}
+
+// parseDate parses dates like "2019-12-31" or the approximate ones, like "2027-10"
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", "2006-01"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
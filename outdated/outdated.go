@@ -0,0 +1,97 @@
+// Package outdated finds installed versions and versions pinned by the projects
+// which have newer releases or are past their upstream end-of-life
+package outdated
+
+import (
+	"time"
+
+	"github.com/markelog/eclectica/versions"
+)
+
+// Version is the state of the installed or pinned version
+type Version struct {
+	Version string
+
+	// Patch is the newest release in the line of the version, like "8.9.4" for "8.9.0",
+	// it's empty for masks, since they are always resolved to the newest one
+	Patch string
+
+	// EOL is the end-of-life date of the line, zero if it's unknown
+	EOL time.Time
+}
+
+// Report is the state of the installed language versions
+type Report struct {
+	Language string
+	Current  string
+
+	// Newest is the newest release of the language
+	Newest string
+
+	Versions []*Version
+}
+
+// New composes report of the installed versions against remote ones and end-of-life dates
+func New(language, current string, installed, remote []string, dates Dates) *Report {
+	report := &Report{
+		Language: language,
+		Current:  current,
+		Versions: []*Version{},
+	}
+
+	report.Newest, _ = versions.Newest("latest", remote, false)
+
+	for _, version := range installed {
+		report.Versions = append(report.Versions, Check(version, remote, dates))
+	}
+
+	return report
+}
+
+// Check gets state of the version against remote ones and end-of-life dates
+func Check(version string, remote []string, dates Dates) *Version {
+	result := &Version{
+		Version: version,
+		EOL:     dates.Find(version),
+	}
+
+	// Without remote versions only end-of-life could be known
+	if len(remote) == 0 {
+		return result
+	}
+
+	parsed, err := versions.Parse(version)
+	if err != nil || versions.IsPartial(version) {
+		return result
+	}
+
+	patch, err := versions.Newest(versions.Line(version), remote, parsed.IsPrerelease())
+	if err != nil {
+		result.Patch = version
+		return result
+	}
+
+	if newest, errParse := versions.Parse(patch); errParse == nil && parsed.LessThan(newest) {
+		result.Patch = patch
+	} else {
+		result.Patch = version
+	}
+
+	return result
+}
+
+// HasPatch checks if there is a newer release in the line of the version
+func (version *Version) HasPatch() bool {
+	return version.Patch != "" && version.Patch != version.Version
+}
+
+// IsEOL checks if the line of the version is past its end-of-life
+func (version *Version) IsEOL(now time.Time) bool {
+	return version.EOL.IsZero() == false && version.EOL.Before(now)
+}
+
+// IsOutdated checks if there is a newer release in the line
+// or the line of the version is past its end-of-life
+func (version *Version) IsOutdated(now time.Time) bool {
+	return version.HasPatch() || version.IsEOL(now)
+}
@@ -0,0 +1,284 @@
+package outdated_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/outdated"
+	"github.com/markelog/eclectica/request"
+)
+
+var _ = Describe("outdated", func() {
+	var (
+		home  string
+		now   = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+		dates = Dates{
+			"0.10": time.Date(2016, 10, 31, 0, 0, 0, 0, time.UTC),
+			"8":    time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC),
+		}
+		remote = []string{"0.10.47", "0.10.48", "8.9.0", "8.9.4", "8.10.0", "9.3.0", "10.0.0-rc1"}
+	)
+
+	BeforeEach(func() {
+		home = filepath.Join(os.TempDir(), "eclectica-outdated-home")
+
+		os.Setenv("EC_HOME", home)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_HOME")
+		os.RemoveAll(home)
+	})
+
+	Describe("Check", func() {
+		It("should find newer release in the line", func() {
+			version := Check("8.9.0", remote, dates)
+
+			Expect(version.Patch).To(Equal("8.9.4"))
+			Expect(version.HasPatch()).To(Equal(true))
+			Expect(version.IsOutdated(now)).To(Equal(true))
+		})
+
+		It("should not consider the newest release as outdated", func() {
+			version := Check("8.10.0", remote, dates)
+
+			Expect(version.Patch).To(Equal("8.10.0"))
+			Expect(version.IsOutdated(now)).To(Equal(false))
+		})
+
+		It("should flag versions past end-of-life", func() {
+			version := Check("0.10.48", remote, dates)
+
+			Expect(version.HasPatch()).To(Equal(false))
+			Expect(version.IsEOL(now)).To(Equal(true))
+			Expect(version.IsOutdated(now)).To(Equal(true))
+		})
+
+		It("should not flag versions without end-of-life date", func() {
+			version := Check("9.3.0", remote, dates)
+
+			Expect(version.EOL.IsZero()).To(Equal(true))
+			Expect(version.IsEOL(now)).To(Equal(false))
+		})
+
+		It("should only check end-of-life for masks", func() {
+			version := Check("8", remote, dates)
+
+			Expect(version.Patch).To(Equal(""))
+			Expect(version.EOL).To(Equal(dates["8"]))
+		})
+
+		It("should only check end-of-life without remote versions", func() {
+			version := Check("0.10.47", nil, dates)
+
+			Expect(version.Patch).To(Equal(""))
+			Expect(version.IsEOL(now)).To(Equal(true))
+		})
+
+		It("should not fail if line is not available anymore", func() {
+			version := Check("7.0.0", remote, dates)
+
+			Expect(version.Patch).To(Equal("7.0.0"))
+			Expect(version.HasPatch()).To(Equal(false))
+		})
+	})
+
+	Describe("New", func() {
+		It("should compose the report", func() {
+			report := New("node", "8.9.0", []string{"8.9.0", "0.10.48"}, remote, dates)
+
+			Expect(report.Language).To(Equal("node"))
+			Expect(report.Current).To(Equal("8.9.0"))
+			Expect(report.Newest).To(Equal("9.3.0"))
+			Expect(report.Versions).To(HaveLen(2))
+			Expect(report.Versions[0].Patch).To(Equal("8.9.4"))
+			Expect(report.Versions[1].IsEOL(now)).To(Equal(true))
+		})
+	})
+
+	Describe("Dates", func() {
+		It("should find date by major and minor", func() {
+			Expect(dates.Find("0.10.48")).To(Equal(dates["0.10"]))
+		})
+
+		It("should find date by major", func() {
+			Expect(dates.Find("8.9.4")).To(Equal(dates["8"]))
+		})
+
+		It("should return zero date for unknown line", func() {
+			Expect(dates.Find("9.3.0").IsZero()).To(Equal(true))
+			Expect(dates.Find("latest").IsZero()).To(Equal(true))
+		})
+	})
+
+	Describe("EOL", func() {
+		transport := request.Transport
+
+		BeforeEach(func() {
+			httpmock.Activate()
+			request.Transport = httpmock.DefaultTransport
+		})
+
+		AfterEach(func() {
+			httpmock.DeactivateAndReset()
+			request.Transport = transport
+		})
+
+		It("should parse node.js schedule", func() {
+			httpmock.RegisterResponder("GET", Sources["node"], httpmock.NewStringResponder(200, `{
+				"v0.10": {"start": "2013-03-11", "end": "2016-10-31"},
+				"v8": {"start": "2017-05-30", "lts": "2017-10-31", "end": "2019-12-31"},
+				"v9": {"start": "2017-10-01"}
+			}`))
+
+			result, err := EOL("node")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+			Expect(result["0.10"]).To(Equal(time.Date(2016, 10, 31, 0, 0, 0, 0, time.UTC)))
+			Expect(result["8"]).To(Equal(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should parse python release cycle", func() {
+			httpmock.RegisterResponder("GET", Sources["python"], httpmock.NewStringResponder(200, `{
+				"3.14": {"status": "feature", "end_of_life": "2030-10"},
+				"2.7": {"status": "end-of-life", "end_of_life": "2020-01-01"}
+			}`))
+
+			result, err := EOL("python")
+
+			Expect(err).To(BeNil())
+			Expect(result["2.7"]).To(Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(result["3.14"]).To(Equal(time.Date(2030, 10, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should parse ruby branches", func() {
+			httpmock.RegisterResponder("GET", Sources["ruby"], httpmock.NewStringResponder(200, `
+- name: 3.4
+  status: normal maintenance
+  date: 2024-12-25
+  eol_date:
+  expected_eol_date: 2028-03
+
+- name: 2.4
+  status: eol
+  date: 2016-12-25
+  eol_date: 2020-03-31
+  expected_eol_date: 2020-03
+`))
+
+			result, err := EOL("ruby")
+
+			Expect(err).To(BeNil())
+			Expect(result["3.4"]).To(Equal(time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(result["2.4"]).To(Equal(time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should cache the metadata", func() {
+			httpmock.RegisterResponder("GET", Sources["node"], httpmock.NewStringResponder(200, `{
+				"v8": {"end": "2019-12-31"}
+			}`))
+
+			EOL("node")
+			result, err := EOL("node")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			Expect(httpmock.GetTotalCallCount()).To(Equal(1))
+		})
+
+		It("should return an error for malformed metadata", func() {
+			httpmock.RegisterResponder("GET", Sources["node"], httpmock.NewStringResponder(200, "<html>"))
+
+			_, err := EOL("node")
+
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return no dates for languages without metadata", func() {
+			result, err := EOL("rust")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(BeEmpty())
+		})
+	})
+
+	Describe("Scan", func() {
+		var root string
+
+		BeforeEach(func() {
+			root = filepath.Join(os.TempDir(), "eclectica-outdated-projects")
+
+			os.MkdirAll(filepath.Join(root, "api"), 0755)
+			os.MkdirAll(filepath.Join(root, "web", "src"), 0755)
+			os.MkdirAll(filepath.Join(root, "web", "node_modules", "dep"), 0755)
+			os.MkdirAll(filepath.Join(root, ".cache"), 0755)
+
+			io.WriteFile(filepath.Join(root, "api", ".go-version"), "1.9.0")
+			io.WriteFile(filepath.Join(root, "api", ".tool-versions"), "nodejs 8.9.0\n")
+			io.WriteFile(filepath.Join(root, "web", ".node-version"), "6")
+			io.WriteFile(filepath.Join(root, "web", "node_modules", "dep", ".node-version"), "0.10.48")
+			io.WriteFile(filepath.Join(root, ".cache", ".node-version"), "0.10.48")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(root)
+		})
+
+		It("should find versions pinned in the tree", func() {
+			pins, err := Scan(root)
+
+			Expect(err).To(BeNil())
+			Expect(pins).To(HaveLen(3))
+
+			found := map[string]string{}
+			for _, pin := range pins {
+				rel, _ := filepath.Rel(root, pin.Path)
+				found[pin.Language+" "+pin.Version] = rel
+			}
+
+			Expect(found).To(Equal(map[string]string{
+				"go 1.9.0":   filepath.Join("api", ".go-version"),
+				"node 8.9.0": filepath.Join("api", ".tool-versions"),
+				"node 6":     filepath.Join("web", ".node-version"),
+			}))
+		})
+
+		It("should skip dot files which could not be read", func() {
+			broken := filepath.Join(root, "broken", ".python-version")
+
+			// Directory could be opened, but not read
+			os.MkdirAll(broken, 0755)
+			os.MkdirAll(filepath.Join(root, "broken", "src"), 0755)
+
+			pins, err := Scan(root)
+
+			Expect(err).To(BeNil())
+			Expect(pins).To(HaveLen(4))
+
+			failed := []*Pin{}
+			for _, pin := range pins {
+				if pin.Error != nil {
+					failed = append(failed, pin)
+				}
+			}
+
+			Expect(failed).To(HaveLen(1))
+			Expect(failed[0].Language).To(Equal("python"))
+			Expect(failed[0].Path).To(Equal(broken))
+			Expect(failed[0].Version).To(Equal(""))
+		})
+
+		It("should return an error for nonexistent folder", func() {
+			_, err := Scan(filepath.Join(root, "nope"))
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
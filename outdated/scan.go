@@ -0,0 +1,136 @@
+package outdated
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/versions"
+)
+
+// skip are the folders which are not scanned, since they belong to the dependencies
+var skip = []string{"node_modules", "vendor", "bower_components"}
+
+// Pin is the version pinned by the project
+type Pin struct {
+	Language string
+	Version  string
+
+	// Path to the dot file where version was defined
+	Path string
+
+	// Error is why the dot file could not be read, version is not defined then
+	Error error
+}
+
+// Scan finds versions pinned by the dot files in the folder tree,
+// hidden and dependency folders are skipped. Dot files which could not be read
+// do not stop the scan, they are returned with the error instead of the version
+func Scan(root string) (result []*Pin, err error) {
+	result = []*Pin{}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, errWalk error) error {
+		// Unreadable folders inside of the tree are not essential
+		if errWalk != nil && path != root {
+			return nil
+		}
+
+		if errWalk != nil {
+			return errWalk
+		}
+
+		if info.IsDir() == false {
+			return nil
+		}
+
+		if path != root && isSkipped(info.Name()) {
+			return filepath.SkipDir
+		}
+
+		pins, errPins := pinned(path)
+		if errPins != nil {
+			return errPins
+		}
+
+		result = append(result, pins...)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return result, nil
+}
+
+// pinned gets versions defined by the dot files in the folder itself
+func pinned(folder string) (result []*Pin, err error) {
+	broken := map[string]bool{}
+
+	for _, language := range plugins.Plugins {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		version, path, errVersion := plugin.LocalVersion(folder)
+		if errVersion != nil {
+			path, _ = io.FindDotFile(append(plugin.Dots(), io.ToolVersions), folder)
+
+			// Multi-language file is reported once and only for the folder where it is located
+			if filepath.Dir(path) != folder || broken[path] {
+				continue
+			}
+
+			broken[path] = true
+			result = append(result, &Pin{
+				Language: language,
+				Path:     path,
+				Error:    errVersion,
+			})
+
+			continue
+		}
+
+		if version == "current" || version == versions.System {
+			continue
+		}
+
+		// Version is defined somewhere up in the tree
+		if filepath.Dir(path) != folder {
+			continue
+		}
+
+		result = append(result, &Pin{
+			Language: language,
+			Version:  version,
+			Path:     path,
+		})
+	}
+
+	return
+}
+
+// isSkipped checks if folder shouldn't be scanned
+func isSkipped(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	for _, elem := range skip {
+		if elem == name {
+			return true
+		}
+	}
+
+	return false
+}
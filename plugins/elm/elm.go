@@ -11,14 +11,15 @@ import (
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/blang/semver"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
 	"github.com/markelog/cprf"
 
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
@@ -27,7 +28,7 @@ var (
 
 	versionPattern = "\\d+\\.\\d+\\.\\d+"
 
-	diffFolderBinaryName, _ = semver.Make("0.17.1")
+	diffFolderBinaryName, _ = versions.Parse("0.17.1")
 
 	bins = []string{"elm", "elm-make", "elm-package", "elm-reactor", "elm-repl"}
 	dots = []string{".elm-version"}
@@ -91,13 +92,14 @@ func (elm Elm) PostInstall() error {
 // Info provides all the info needed for installation of the plugin
 func (elm Elm) Info() map[string]string {
 	var (
-		result     = make(map[string]string)
-		sourcesURL = fmt.Sprintf("%s/%s", VersionLink, elm.Version)
-		chosen, _  = semver.Make(elm.Version)
+		result      = make(map[string]string)
+		link        = variables.Mirror("elm").Download(VersionLink)
+		sourcesURL  = fmt.Sprintf("%s/%s", link, elm.Version)
+		chosen, err = versions.Parse(elm.Version)
 	)
 
 	// Man, why?!
-	if chosen.LT(diffFolderBinaryName) {
+	if err != nil || chosen.LessThan(diffFolderBinaryName) {
 		result["unarchive-filename"] = "dist_binaries"
 	}
 	if runtime.GOOS == "linux" {
@@ -131,7 +133,8 @@ func (elm Elm) Dots() []string {
 
 // ListRemote returns list of the all available remote versions
 func (elm Elm) ListRemote() ([]string, error) {
-	doc, err := goquery.NewDocument(VersionLink)
+	mirror := variables.Mirror("elm")
+	doc, err := request.Document(mirror.Listing(mirror.Download(VersionLink)))
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
@@ -16,6 +16,7 @@ import (
 	"github.com/go-errors/errors"
 
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
 )
@@ -87,11 +88,12 @@ func (golang Golang) Info() map[string]string {
 
 	platform, _ := getPlatform()
 
-	version := versions.Unsemverify(golang.Version)
+	version := versions.Format("go", golang.Version)
 	result["version"] = version
 	result["unarchive-filename"] = "go"
 	result["filename"] = fmt.Sprintf("go%s.%s", version, platform)
-	result["url"] = fmt.Sprintf("%s/%s.tar.gz", DownloadLink, result["filename"])
+	link := variables.Mirror("go").Download(DownloadLink)
+	result["url"] = fmt.Sprintf("%s/%s.tar.gz", link, result["filename"])
 
 	return result
 }
@@ -115,7 +117,7 @@ func (golang Golang) ListRemote() (result []string, err error) {
 		selector      = "#archive tr:first-of-type td:first-of-type.filename a"
 	)
 
-	doc, err := goquery.NewDocument(VersionLink)
+	doc, err := request.Document(variables.Mirror("go").Listing(VersionLink))
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
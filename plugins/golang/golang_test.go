@@ -20,6 +20,7 @@ import (
 	. "github.com/markelog/eclectica/plugins/golang"
 
 	eIO "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -111,10 +112,13 @@ var _ = Describe("golang", func() {
 	})
 
 	Describe("Info", func() {
+		transport := request.Transport
+
 		BeforeEach(func() {
 			content := eIO.Read("../../testdata/plugins/golang/latest.txt")
 
 			httpmock.Activate()
+			request.Transport = httpmock.DefaultTransport
 
 			httpmock.RegisterResponder(
 				"GET",
@@ -130,7 +134,8 @@ var _ = Describe("golang", func() {
 		})
 
 		AfterEach(func() {
-			defer httpmock.DeactivateAndReset()
+			httpmock.DeactivateAndReset()
+			request.Transport = transport
 		})
 
 		It("should get info about 1.7 version", func() {
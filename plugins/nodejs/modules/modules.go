@@ -7,10 +7,10 @@ import (
 	"os/exec"
 	"path/filepath"
 
-	"github.com/blang/semver"
 	"github.com/go-errors/errors"
 	"github.com/markelog/cprf"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 // Modules essential struct
@@ -181,8 +181,12 @@ func (modules Modules) Path(version string) string {
 // SameMajors compares previous and current node version
 // returning true if they have same major versions
 func (modules Modules) SameMajors() bool {
-	previous, _ := semver.Make(modules.previous)
-	current, _ := semver.Make(modules.current)
+	previous, errPrevious := versions.Parse(modules.previous)
+	current, errCurrent := versions.Parse(modules.current)
+
+	if errPrevious != nil || errCurrent != nil {
+		return false
+	}
 
 	return previous.Major == current.Major
 }
@@ -9,13 +9,14 @@ import (
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/blang/semver"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
 
 	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/plugins/nodejs/modules"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
@@ -24,7 +25,7 @@ var (
 
 	versionPattern = "v\\d+\\.\\d+\\.\\d+$"
 
-	minimalVersion, _ = semver.Make("0.10.0")
+	minimalVersion, _ = versions.Parse("0.10.0")
 
 	bins = []string{"node", "npm"}
 	dots = []string{".nvmrc", ".node-version"}
@@ -82,8 +83,9 @@ func (node Node) Switch() (err error) {
 		return
 	}
 
-	// If there is no previous version – don't do anything
-	if len(previous) == 0 {
+	// If there is no previous version – don't do anything,
+	// modules of the system version are not managed by us
+	if len(previous) == 0 || previous == versions.System {
 		return
 	}
 
@@ -100,7 +102,8 @@ func (node Node) Switch() (err error) {
 // Info provides all the info needed for installation of the plugin
 func (node Node) Info() map[string]string {
 	result := make(map[string]string)
-	sourcesURL := fmt.Sprintf("%s/v%s", VersionLink, node.Version)
+	link := variables.Mirror("node").Download(VersionLink)
+	sourcesURL := fmt.Sprintf("%s/v%s", link, node.Version)
 
 	result["filename"] = fmt.Sprintf("node-v%s-%s-x64", node.Version, runtime.GOOS)
 	result["url"] = fmt.Sprintf("%s/%s.tar.gz", sourcesURL, result["filename"])
@@ -122,7 +125,8 @@ func (node Node) Dots() []string {
 
 // ListRemote returns list of the all available remote versions
 func (node Node) ListRemote() ([]string, error) {
-	doc, err := goquery.NewDocument(VersionLink)
+	mirror := variables.Mirror("node")
+	doc, err := request.Document(mirror.Listing(mirror.Download(VersionLink)))
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
@@ -149,9 +153,9 @@ func (node Node) ListRemote() ([]string, error) {
 
 	// Remove outdated versions
 	for _, element := range tmp {
-		version, _ := semver.Make(element)
+		version, err := versions.Parse(element)
 
-		if version.GTE(minimalVersion) {
+		if err == nil && version.LessThan(minimalVersion) == false {
 			result = append(result, element)
 		}
 	}
@@ -13,6 +13,7 @@ import (
 
 	eio "github.com/markelog/eclectica/io"
 	. "github.com/markelog/eclectica/plugins/nodejs"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -91,10 +92,13 @@ var _ = Describe("nodejs", func() {
 	})
 
 	Describe("Info", func() {
+		transport := request.Transport
+
 		BeforeEach(func() {
 			content := eio.Read("../../testdata/plugins/nodejs/latest.txt")
 
 			httpmock.Activate()
+			request.Transport = httpmock.DefaultTransport
 
 			httpmock.RegisterResponder(
 				"GET",
@@ -110,7 +114,8 @@ var _ = Describe("nodejs", func() {
 		})
 
 		AfterEach(func() {
-			defer httpmock.DeactivateAndReset()
+			httpmock.DeactivateAndReset()
+			request.Transport = transport
 		})
 
 		It("should get info about 6.3.1 version", func() {
@@ -6,17 +6,17 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/blang/semver"
 	"github.com/markelog/archive"
 	"github.com/markelog/cprf"
-	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
-	minimalForYarn, _ = semver.Make("4.0.0")
+	minimalForYarn, _ = versions.Parse("4.0.0")
 	version           = "1.5.1"
 	yarnURL           = fmt.Sprintf(
 		"https://yarnpkg.com/downloads/%s/yarn-v%s.tar.gz",
@@ -25,19 +25,17 @@ var (
 	)
 )
 
-func (node Node) download(path string) (err error) {
-	_, err = grab.Get(path, yarnURL)
-	if err != nil {
-		return
-	}
-
-	return
+func (node Node) download(path string) error {
+	return request.File(yarnURL, path)
 }
 
 func (node Node) isYarnPossible() bool {
-	version, _ := semver.Make(node.Version)
+	version, err := versions.Parse(node.Version)
+	if err != nil {
+		return false
+	}
 
-	return version.GTE(minimalForYarn)
+	return version.LessThan(minimalForYarn) == false
 }
 
 // Yarn does everything that needs to be done to install yarn
@@ -9,16 +9,16 @@ import (
 	"github.com/bouk/monkey"
 	"github.com/markelog/archive"
 	"github.com/markelog/cprf"
-	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/eclectica/io"
 	. "github.com/markelog/eclectica/plugins/nodejs"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
 var _ = Describe("yarn", func() {
 	var (
-		grabGet        bool
+		requestFile    bool
 		archiveExtract bool
 		cprfCopy       bool
 		osRemovaAll    bool
@@ -26,15 +26,15 @@ var _ = Describe("yarn", func() {
 	)
 
 	BeforeEach(func() {
-		grabGet = false
+		requestFile = false
 		archiveExtract = false
 		cprfCopy = false
 		osRemovaAll = false
 		ioSymlink = false
 
-		monkey.Patch(grab.Get, func(path, url string) (*grab.Response, error) {
-			grabGet = true
-			return nil, nil
+		monkey.Patch(request.File, func(url, path string) error {
+			requestFile = true
+			return nil
 		})
 
 		monkey.Patch(archive.Extract, func(from, to string) error {
@@ -59,7 +59,7 @@ var _ = Describe("yarn", func() {
 	})
 
 	AfterEach(func() {
-		monkey.Unpatch(grab.Get)
+		monkey.Unpatch(request.File)
 		monkey.Unpatch(archive.Extract)
 		monkey.Unpatch(cprf.Copy)
 		monkey.Unpatch(os.RemoveAll)
@@ -69,7 +69,7 @@ var _ = Describe("yarn", func() {
 	It("should not try to install yarn for unsupported node version", func() {
 		working, err := (&Node{Version: "0.10.0"}).Yarn()
 
-		Expect(grabGet).To(Equal(false))
+		Expect(requestFile).To(Equal(false))
 		Expect(archiveExtract).To(Equal(false))
 		Expect(cprfCopy).To(Equal(false))
 		Expect(osRemovaAll).To(Equal(false))
@@ -88,7 +88,7 @@ var _ = Describe("yarn", func() {
 
 		working, err := (&Node{Version: "6.10.0"}).Yarn()
 
-		Expect(grabGet).To(Equal(true))
+		Expect(requestFile).To(Equal(true))
 		Expect(archiveExtract).To(Equal(true))
 		Expect(cprfCopy).To(Equal(true))
 		Expect(osRemovaAll).To(Equal(true))
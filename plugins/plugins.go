@@ -6,16 +6,20 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
 	"github.com/kardianos/osext"
 	"github.com/markelog/archive"
 	"github.com/markelog/cprf"
-	"gopkg.in/cavaliercoder/grab.v1"
 
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/config"
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/pkg"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/shell"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
@@ -35,8 +39,10 @@ type Plugin struct {
 	Pkg     pkg.Pkg
 	emitter *emission.Emitter
 
-	name string
-	info map[string]string
+	name      string
+	format    string
+	info      map[string]string
+	interrupt chan os.Signal
 }
 
 // Args is arguments struct for New() method
@@ -44,6 +50,9 @@ type Args struct {
 	Language    string
 	Version     string
 	WithModules bool
+
+	// Format of the dot file for the local version, configured one is used if empty
+	Format string
 }
 
 var (
@@ -57,6 +66,13 @@ var (
 		"python",
 		"elm",
 	}
+
+	// names holds alternative language names, under which
+	// languages could be defined in the multi-language file
+	names = map[string][]string{
+		"node": {"nodejs"},
+		"go":   {"golang"},
+	}
 )
 
 // New returns new plugin struct
@@ -64,9 +80,14 @@ func New(args *Args) *Plugin {
 	plugin := &Plugin{
 		name:    args.Language,
 		Version: args.Version,
+		format:  args.Format,
 		emitter: emission.NewEmitter(),
 	}
 
+	if plugin.format == "" {
+		plugin.format = variables.LocalFormat()
+	}
+
 	switch {
 	case args.Language == "node":
 		plugin.Pkg = nodejs.New(&nodejs.Args{
@@ -100,6 +121,10 @@ func (plugin *Plugin) Events() *emission.Emitter {
 
 // PreDownload executes logic before downloading of the plugin
 func (plugin *Plugin) PreDownload() error {
+	if plugin.Version == versions.System {
+		return nil
+	}
+
 	return plugin.Pkg.PreDownload()
 }
 
@@ -146,6 +171,7 @@ func (plugin *Plugin) LocalInstall() (err error) {
 
 	err = plugin.finishLocal()
 	if err != nil {
+		plugin.Rollback()
 		return
 	}
 
@@ -162,19 +188,75 @@ func (plugin Plugin) finishLocal() (err error) {
 		return
 	}
 
-	var (
-		version = fmt.Sprintf(".%s-version", plugin.name)
-		path    = filepath.Join(pwd, version)
-	)
+	path, err := plugin.LocalPath(pwd)
+	if err != nil {
+		return
+	}
 
 	err = plugin.Switch()
 	if err != nil {
 		return
 	}
 
-	err = io.WriteFile(path, plugin.Version)
+	// Nothing is installed here, so there is nothing to roll back either
+	err = io.WriteDotFile(path, plugin.Names(), plugin.Version)
+	if err != nil {
+		return
+	}
+
+	plugin.emitter.Emit("done")
+
+	return
+}
+
+// LocalPath gets path of the dot file where local version for the folder is written,
+// with "auto" format the dot file which already defines the version is updated
+func (plugin *Plugin) LocalPath(pwd string) (string, error) {
+	switch plugin.format {
+	case "auto":
+		_, path, err := io.FindVersion(plugin.Names(), plugin.Dots(), pwd)
+		if err != nil {
+			return "", err
+		}
+
+		if path != "" {
+			return path, nil
+		}
+	case "tool-versions":
+		return filepath.Join(pwd, io.ToolVersions), nil
+	case "language":
+	default:
+		return "", errors.New(
+			"Format should be one of \"" + strings.Join(config.LocalFormats, "\", \"") + "\"",
+		)
+	}
+
+	return filepath.Join(pwd, fmt.Sprintf(".%s-version", plugin.name)), nil
+}
+
+// BareInstall installs the plugin without switching to it, useful
+// when version is already defined by the dot files of the project.
+// Note: shell should be initiated by the consumer
+func (plugin *Plugin) BareInstall() (err error) {
+	if plugin.Version == "" {
+		return errors.New("version was not defined")
+	}
+
+	// Handle CTRL+C signal
+	plugin.Interrupt()
+
+	if plugin.IsInstalled() {
+		plugin.emitter.Emit("done")
+		return nil
+	}
+
+	err = plugin.PreInstall()
+	if err != nil {
+		return
+	}
+
+	err = plugin.Done()
 	if err != nil {
-		plugin.Rollback()
 		return
 	}
 
@@ -183,6 +265,27 @@ func (plugin Plugin) finishLocal() (err error) {
 	return
 }
 
+// Activate switches to the already installed version, either globally or
+// for the current folder only, without touching the shell.
+// Note: shell should be initiated by the consumer
+func (plugin *Plugin) Activate(local bool) (err error) {
+	if plugin.IsInstalled() == false {
+		return errors.New("version " + plugin.Version + " is not installed")
+	}
+
+	if local {
+		return plugin.finishLocal()
+	}
+
+	// If this is already a current version there is nothing to do
+	if plugin.Version == plugin.Current() {
+		plugin.emitter.Emit("done")
+		return nil
+	}
+
+	return plugin.finishInstall()
+}
+
 // Install the plugin
 func (plugin *Plugin) Install() (err error) {
 	err = plugin.PreInstall()
@@ -280,6 +383,11 @@ func (plugin *Plugin) PostInstall() (err error) {
 
 // Switch executes logic before switching plugin versions
 func (plugin *Plugin) Switch() (err error) {
+	// System version is not installed by us, so it only needs the proxies
+	if plugin.Version == versions.System {
+		return plugin.Proxy()
+	}
+
 	err = plugin.Pkg.Switch()
 	if err != nil {
 		plugin.Rollback()
@@ -336,6 +444,11 @@ func (plugin *Plugin) Info() (map[string]string, error) {
 	return info, nil
 }
 
+// ArchivePath returns path where archive of the version is downloaded
+func (plugin *Plugin) ArchivePath() string {
+	return plugin.info["archive-path"]
+}
+
 // Current returns current used version
 func (plugin *Plugin) Current() string {
 	return variables.CurrentVersion(plugin.name)
@@ -375,17 +488,35 @@ func (plugin *Plugin) Done() (err error) {
 // Interrupt handles interruption signals (like CTRL+C)
 func (plugin *Plugin) Interrupt() {
 	channel := make(chan os.Signal, 1)
+	plugin.interrupt = channel
 
 	plugin.emitter.Emit("done")
 	signal.Notify(channel, os.Interrupt)
 
 	go func() {
-		<-channel
+		// Channel is closed when handler is released
+		if _, ok := <-channel; ok == false {
+			return
+		}
+
 		plugin.Rollback()
 		os.Exit(1)
 	}()
 }
 
+// Release stops handling of the interruption signals,
+// so finished installation is not rolled back anymore
+func (plugin *Plugin) Release() {
+	if plugin.interrupt == nil {
+		return
+	}
+
+	signal.Stop(plugin.interrupt)
+	close(plugin.interrupt)
+
+	plugin.interrupt = nil
+}
+
 // Remove the plugin
 func (plugin *Plugin) Remove() (err error) {
 	if plugin.Version == "" {
@@ -397,16 +528,28 @@ func (plugin *Plugin) Remove() (err error) {
 		base = filepath.Join(home, plugin.Version)
 	)
 
-	// Need to remove proxies if this is a current version.
-	// So we wouldn't confuse the user
+	// Need to remove proxies and the link to the current version
+	// if this is a current one, so language is left without current version
+	// instead of the proxies pointing to nowhere
 	if plugin.Current() == plugin.Version {
 		err = plugin.removeProxy()
 		if err != nil {
 			return err
 		}
+
+		err = os.RemoveAll(variables.Path(plugin.name))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Externally built version is only unlinked, its prefix is never touched
+	if variables.LinkTarget(plugin.name, plugin.Version) != "" {
+		err = plugin.unlink()
+	} else {
+		err = os.RemoveAll(base)
 	}
 
-	err = os.RemoveAll(base)
 	if err != nil {
 		return err
 	}
@@ -426,39 +569,87 @@ func (plugin *Plugin) Remove() (err error) {
 	return os.RemoveAll(variables.Prefix(plugin.name))
 }
 
-// Download the plugin
-func (plugin *Plugin) Download() (*grab.Response, error) {
+// Register links externally built version, like the one installed by the
+// distro package, so it could be used as any other installed version.
+// Note: proxies should be set up by the consumer
+func (plugin *Plugin) Register(prefix string) (err error) {
 	if plugin.Version == "" {
-		return nil, errors.New("version was not defined")
+		return errors.New("version was not defined")
 	}
 
-	// If already downloaded
-	if _, err := os.Stat(plugin.info["destination-folder"]); err == nil {
-		return nil, nil
+	// Custom builds could be marked with the suffix, like "8.9.4-custom",
+	// but release should be full, otherwise version could not be selected
+	release := strings.SplitN(plugin.Version, "-", 2)[0]
+	parsed, errParse := versions.Parse(release)
+	if errParse != nil || parsed.IsPartial() || versions.IsPartial(plugin.Version) ||
+		strings.ContainsRune(plugin.Version, filepath.Separator) {
+		return errors.New("Version should look like \"8.9.4\" or \"8.9.4-custom\", not \"" + plugin.Version + "\"")
 	}
 
-	response, err := grab.GetAsync(plugin.info["archive-folder"], plugin.info["url"])
+	prefix, err = filepath.Abs(prefix)
 	if err != nil {
-		return nil, err
+		return errors.New(err)
+	}
+
+	if stat, errStat := os.Stat(filepath.Join(prefix, "bin")); errStat != nil || stat.IsDir() == false {
+		return errors.New("\"" + prefix + "\" does not look like a prefix of " + plugin.name + ", there is no \"bin\" folder")
 	}
 
-	resp := <-response
+	path := variables.Path(plugin.name, plugin.Version)
+	if _, errStat := os.Lstat(path); errStat == nil {
+		return errors.New("Version \"" + plugin.Version + "\" of " + plugin.name + " is already installed")
+	}
 
-	if resp == nil {
-		return resp, errors.New(variables.ConnectionError)
+	_, err = io.CreateDir(variables.Prefix(plugin.name))
+	if err != nil {
+		return
 	}
 
-	if resp.HTTPResponse == nil {
-		return resp, errors.New(variables.ConnectionError)
+	err = os.Symlink(prefix, path)
+	if err != nil {
+		return errors.New(err)
 	}
 
-	if resp.HTTPResponse.StatusCode == 404 {
-		grab.NewClient().CancelRequest(resp.Request)
+	_, err = io.CreateDir(variables.Links(plugin.name))
+	if err == nil {
+		err = io.WriteFile(filepath.Join(variables.Links(plugin.name), plugin.Version), prefix)
+	}
 
-		return resp, errors.New("Incorrect version " + plugin.Version)
+	if err != nil {
+		os.Remove(path)
+		return
 	}
 
-	return resp, nil
+	return nil
+}
+
+// Download the plugin, if archive was partially downloaded
+// before, download continues from where it stopped
+func (plugin *Plugin) Download() (*request.Response, error) {
+	if plugin.Version == "" {
+		return nil, errors.New("version was not defined")
+	}
+
+	// System version is already there
+	if plugin.Version == versions.System {
+		return nil, nil
+	}
+
+	// If already downloaded
+	if _, err := os.Stat(plugin.info["destination-folder"]); err == nil {
+		return nil, nil
+	}
+
+	response, err := request.Download(plugin.info["url"], plugin.info["archive-path"])
+	if err != nil {
+		if statusErr, ok := err.(*request.StatusError); ok && statusErr.Code == 404 {
+			return nil, errors.New("Incorrect version " + plugin.Version)
+		}
+
+		return nil, err
+	}
+
+	return response, nil
 }
 
 // Extract raw files from the downloaded archive (its always an archive)
@@ -525,6 +716,85 @@ func (plugin *Plugin) Dots() []string {
 	return plugin.Pkg.Dots()
 }
 
+// Names returns all names under which the language
+// could be defined in the multi-language file
+func (plugin *Plugin) Names() []string {
+	return append([]string{plugin.name}, names[plugin.name]...)
+}
+
+// LocalVersion gets the version defined either in the language specific dot files
+// or in the multi-language file for the provided (or current) path, aliases are resolved
+// to the versions they point to, returns "current" if there is no version defined
+func (plugin *Plugin) LocalVersion(args ...interface{}) (version, path string, err error) {
+	version, path, err = io.FindVersion(plugin.Names(), plugin.Dots(), args...)
+	if err != nil {
+		return
+	}
+
+	return aliases.Resolve(plugin.name, version), path, nil
+}
+
+// ResolveVersion gets installed version defined for the provided (or current) path,
+// masks are resolved to the latest installed version, returns "current" if there is no version defined
+func (plugin *Plugin) ResolveVersion(args ...interface{}) (string, error) {
+	version, path, err := plugin.LocalVersion(args...)
+	if err != nil || version == "current" || version == versions.System {
+		return version, err
+	}
+
+	if versions.IsPartial(version) {
+		version, err = versions.Latest(version, plugin.List())
+		if err != nil {
+			return "", errors.New("None of the versions defined in \"" + path + "\" are installed")
+		}
+	}
+
+	if variables.IsInstalled(plugin.name, version) == false {
+		return "", errors.New("Version \"" + version + "\" defined in \"" + path + "\" is not installed")
+	}
+
+	return version, nil
+}
+
+// Installed gets installed version which matches the provided one, aliases are resolved
+// and masks are matched against the installed versions only, never the remote ones
+func (plugin *Plugin) Installed(version string) (string, error) {
+	version = aliases.Resolve(plugin.name, version)
+
+	// Any of the binaries will do, since not every system has all of them,
+	// like python without un-suffixed "2to3"
+	if version == versions.System {
+		for _, bin := range plugin.Bins() {
+			if _, err := SystemBin(bin); err == nil {
+				return version, nil
+			}
+		}
+
+		return "", errors.New(plugin.name + " is not installed in the system")
+	}
+
+	installed := plugin.List()
+	if len(installed) == 0 {
+		return "", errors.New("There is no installed versions of " + plugin.name)
+	}
+
+	matched := versions.Match(version, installed)
+	if len(matched) == 0 {
+		return "", errors.New(
+			"None of the installed versions of " + plugin.name + " match \"" + version + "\", " +
+				"installed are " + strings.Join(installed, ", "),
+		)
+	}
+
+	// Installed versions are not sorted semantically and
+	// folder names are not always spelled as semver, like "3.7.0b1"
+	if versions.IsPartial(version) {
+		return versions.Highest(matched)
+	}
+
+	return matched[0], nil
+}
+
 // List returns list of the all available local versions
 func (plugin *Plugin) List() (vers []string) {
 	path := variables.Prefix(plugin.name)
@@ -535,7 +805,7 @@ func (plugin *Plugin) List() (vers []string) {
 
 // ListRemote returns list of the all available remote versions
 func (plugin *Plugin) ListRemote() (map[string][]string, error) {
-	vers, err := plugin.Pkg.ListRemote()
+	vers, err := plugin.RemoteVersions()
 
 	if err != nil {
 		return nil, err
@@ -544,6 +814,51 @@ func (plugin *Plugin) ListRemote() (map[string][]string, error) {
 	return versions.Compose(vers), nil
 }
 
+// RemoteVersions returns flat list of the all available remote versions,
+// list is cached for the time defined by the variables.CacheTTL()
+func (plugin *Plugin) RemoteVersions() ([]string, error) {
+	var (
+		path = plugin.cachePath()
+		ttl  = variables.CacheTTL()
+	)
+
+	if stat, err := os.Stat(path); err == nil && time.Since(stat.ModTime()) < ttl {
+		if vers := plugin.CachedVersions(); len(vers) > 0 {
+			return vers, nil
+		}
+	}
+
+	vers, err := plugin.Pkg.ListRemote()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache is not essential, so ignore the errors
+	if ttl > 0 && len(vers) > 0 {
+		io.CreateDir(variables.Cache())
+		io.WriteFile(path, strings.Join(vers, "\n"))
+	}
+
+	return vers, nil
+}
+
+// CachedVersions returns remote versions from the cache even if it is outdated,
+// without requesting them, so it could be used on every key press of the shell completion
+func (plugin *Plugin) CachedVersions() []string {
+	content := strings.TrimSpace(io.Read(plugin.cachePath()))
+
+	if content == "" {
+		return nil
+	}
+
+	return strings.Split(content, "\n")
+}
+
+// cachePath gets path to the cached list of the remote versions
+func (plugin *Plugin) cachePath() string {
+	return filepath.Join(variables.Cache(), plugin.name)
+}
+
 // Link replaces (if needed) and sets symlink for the language
 func (plugin *Plugin) Link() (err error) {
 	var (
@@ -551,6 +866,17 @@ func (plugin *Plugin) Link() (err error) {
 		current = variables.Path(plugin.name)
 	)
 
+	// In the system mode current version is located separately
+	_, err = io.CreateDir(filepath.Dir(current))
+	if err != nil {
+		return
+	}
+
+	// Current version only marks that system one should be used
+	if plugin.Version == versions.System {
+		return io.Symlink(current, versions.System)
+	}
+
 	err = io.Symlink(current, base)
 	if err != nil {
 		return
@@ -571,7 +897,7 @@ func (plugin *Plugin) IsInstalled() bool {
 
 // Proxy installs the proxy for the language
 func (plugin *Plugin) Proxy() (err error) {
-	ecProxyFolder := os.Getenv("EC_PROXY_PLACE")
+	ecProxyFolder := variables.ProxyPlace()
 
 	if ecProxyFolder == "" {
 		ecProxyFolder, err = osext.ExecutableFolder()
@@ -611,6 +937,21 @@ func (plugin *Plugin) Proxy() (err error) {
 	return nil
 }
 
+// unlink removes the link to the externally built version along with its metadata
+func (plugin *Plugin) unlink() error {
+	err := os.Remove(variables.Path(plugin.name, plugin.Version))
+	if err != nil && os.IsNotExist(err) == false {
+		return errors.New(err)
+	}
+
+	err = os.Remove(filepath.Join(variables.Links(plugin.name), plugin.Version))
+	if err != nil && os.IsNotExist(err) == false {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 func (plugin *Plugin) removeProxy() (err error) {
 	bins := plugin.Bins()
 
@@ -641,6 +982,53 @@ func (plugin *Plugin) removeSupport() (err error) {
 	return nil
 }
 
+// SystemBin searches for the binary installed by the operating system,
+// eclectica folders and the proxy itself are excluded, so proxy would never execute itself
+func SystemBin(name string) (string, error) {
+	self, err := osext.Executable()
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	self, _ = filepath.EvalSymlinks(self)
+
+	// Folder of the proxy is not excluded, since it might be shared with the system binaries
+	excluded := []string{variables.Base(), variables.Root()}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || isWithin(dir, excluded) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		stat, err := os.Stat(path)
+		if err != nil || stat.IsDir() || stat.Mode()&0111 == 0 {
+			continue
+		}
+
+		real, err := filepath.EvalSymlinks(path)
+		if err != nil || real == self || isWithin(real, excluded) {
+			continue
+		}
+
+		return path, nil
+	}
+
+	return "", errors.New("\"" + name + "\" is not installed in the system")
+}
+
+// isWithin checks if path is one of the folders or located inside of them
+func isWithin(path string, folders []string) bool {
+	for _, folder := range folders {
+		if path == folder || strings.HasPrefix(path, folder+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SearchBin searches for the actual binary
 func SearchBin(name string) string {
 	bins := map[string][]string{}
@@ -3,12 +3,12 @@ package plugins_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
-	"runtime"
 	"syscall"
 
 	. "github.com/onsi/ginkgo"
@@ -16,6 +16,7 @@ import (
 
 	"github.com/bouk/monkey"
 
+	"github.com/markelog/eclectica/aliases"
 	. "github.com/markelog/eclectica/plugins"
 	"github.com/markelog/eclectica/shell"
 
@@ -593,6 +594,344 @@ var _ = Describe("plugins", func() {
 		})
 	})
 
+	Describe("ResolveVersion", func() {
+		var (
+			home    string
+			project string
+		)
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-resolve-home")
+			project = filepath.Join(os.TempDir(), "eclectica-resolve-project")
+
+			os.Setenv("EC_HOME", home)
+			os.MkdirAll(project, 0755)
+
+			for _, version := range []string{"6.1.0", "6.2.0"} {
+				os.MkdirAll(variables.Path("node", version), 0755)
+				variables.WriteVersion("node", version)
+			}
+
+			plugin = New(&Args{
+				Language: "node",
+			})
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("EC_HOME")
+			os.RemoveAll(home)
+			os.RemoveAll(project)
+		})
+
+		It("should return \"current\" if version is not defined", func() {
+			version, err := plugin.ResolveVersion(project)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("current"))
+		})
+
+		It("should resolve the mask to the latest installed version", func() {
+			eIO.WriteFile(filepath.Join(project, ".node-version"), "6")
+
+			version, err := plugin.ResolveVersion(project)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("6.2.0"))
+		})
+
+		It("should resolve the alias", func() {
+			aliases.Set("node", "legacy", "6.1.0")
+			eIO.WriteFile(filepath.Join(project, ".node-version"), "legacy")
+
+			version, err := plugin.ResolveVersion(project)
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("6.1.0"))
+		})
+
+		It("should return an error if version is not installed", func() {
+			eIO.WriteFile(filepath.Join(project, ".node-version"), "7.0.0")
+
+			_, err := plugin.ResolveVersion(project)
+
+			Expect(err.Error()).To(ContainSubstring(`Version "7.0.0"`))
+		})
+	})
+
+	Describe("Installed", func() {
+		var home string
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-installed-home")
+
+			os.Setenv("EC_HOME", home)
+
+			for _, version := range []string{"6.1.0", "6.2.0", "8.9.0", "8.9.4", "8.10.0"} {
+				os.MkdirAll(variables.Path("node", version), 0755)
+				variables.WriteVersion("node", version)
+			}
+
+			plugin = New(&Args{
+				Language: "node",
+			})
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("EC_HOME")
+			os.RemoveAll(home)
+		})
+
+		It("should match the mask against the installed versions", func() {
+			version, err := plugin.Installed("6")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("6.2.0"))
+		})
+
+		It("should match the newest version, not the last one by name", func() {
+			version, err := plugin.Installed("8")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("8.10.0"))
+		})
+
+		It("should return the version spelled as its folder", func() {
+			for _, version := range []string{"3.6.4", "3.7.0b1"} {
+				os.MkdirAll(variables.Path("python", version), 0755)
+				variables.WriteVersion("python", version)
+			}
+
+			version, err := New(&Args{
+				Language: "python",
+			}).Installed("3.7")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("3.7.0b1"))
+		})
+
+		It("should accept system version with any of the binaries", func() {
+			system := filepath.Join(os.TempDir(), "eclectica-installed-system")
+			path := os.Getenv("PATH")
+
+			os.MkdirAll(system, 0755)
+			ioutil.WriteFile(filepath.Join(system, "python"), []byte(""), 0755)
+			os.Setenv("PATH", system)
+
+			defer os.Setenv("PATH", path)
+			defer os.RemoveAll(system)
+
+			version, err := New(&Args{
+				Language: "python",
+			}).Installed("system")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("system"))
+		})
+
+		It("should match the full version", func() {
+			version, err := plugin.Installed("8.9.0")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("8.9.0"))
+		})
+
+		It("should resolve the alias", func() {
+			aliases.Set("node", "legacy", "6.1.0")
+
+			version, err := plugin.Installed("legacy")
+
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal("6.1.0"))
+		})
+
+		It("should return an error if nothing installed matches", func() {
+			_, err := plugin.Installed("9")
+
+			Expect(err.Error()).To(ContainSubstring(`match "9"`))
+		})
+	})
+
+	Describe("Register", func() {
+		var (
+			home   string
+			prefix string
+		)
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-register-home")
+			prefix = filepath.Join(os.TempDir(), "eclectica-register-prefix")
+
+			os.Setenv("EC_HOME", home)
+			os.MkdirAll(filepath.Join(prefix, "bin"), 0755)
+			eIO.WriteFile(filepath.Join(prefix, "bin", "node"), "")
+
+			plugin = New(&Args{
+				Language: "node",
+				Version:  "8.9.4-custom",
+			})
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("EC_HOME")
+			os.RemoveAll(home)
+			os.RemoveAll(prefix)
+		})
+
+		It("should register the version", func() {
+			err := plugin.Register(prefix)
+
+			Expect(err).To(BeNil())
+			Expect(plugin.IsInstalled()).To(Equal(true))
+			Expect(plugin.List()).To(Equal([]string{"8.9.4-custom"}))
+			Expect(variables.LinkTarget("node", "8.9.4-custom")).To(Equal(prefix))
+		})
+
+		It("should not touch the prefix on removal", func() {
+			plugin.Register(prefix)
+
+			err := plugin.Remove()
+
+			Expect(err).To(BeNil())
+			Expect(plugin.IsInstalled()).To(Equal(false))
+			Expect(filepath.Join(prefix, "bin", "node")).To(BeARegularFile())
+		})
+
+		It("should not register the prefix without binaries", func() {
+			err := plugin.Register(home)
+
+			Expect(err.Error()).To(ContainSubstring(`there is no "bin" folder`))
+		})
+
+		It("should not register the version without full release", func() {
+			err := New(&Args{
+				Language: "node",
+				Version:  "9-custom",
+			}).Register(prefix)
+
+			Expect(err.Error()).To(ContainSubstring(`not "9-custom"`))
+		})
+
+		It("should not register the version twice", func() {
+			plugin.Register(prefix)
+
+			err := plugin.Register(prefix)
+
+			Expect(err.Error()).To(ContainSubstring("is already installed"))
+		})
+	})
+
+	Describe("LocalPath", func() {
+		var project string
+
+		BeforeEach(func() {
+			project = filepath.Join(os.TempDir(), "eclectica-local-project")
+
+			os.MkdirAll(filepath.Join(project, "src"), 0755)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(project)
+		})
+
+		It("should use the language dot file by default", func() {
+			path, err := New(&Args{
+				Language: "node",
+				Format:   "auto",
+			}).LocalPath(project)
+
+			Expect(err).To(BeNil())
+			Expect(path).To(Equal(filepath.Join(project, ".node-version")))
+		})
+
+		It("should update the dot file which defines the version", func() {
+			eIO.WriteFile(filepath.Join(project, ".nvmrc"), "6")
+
+			path, _ := New(&Args{
+				Language: "node",
+				Format:   "auto",
+			}).LocalPath(filepath.Join(project, "src"))
+
+			Expect(path).To(Equal(filepath.Join(project, ".nvmrc")))
+		})
+
+		It("should update the multi-language file which defines the version", func() {
+			eIO.WriteFile(filepath.Join(project, ".tool-versions"), "nodejs 6\n")
+
+			path, _ := New(&Args{
+				Language: "node",
+				Format:   "auto",
+			}).LocalPath(project)
+
+			Expect(path).To(Equal(filepath.Join(project, ".tool-versions")))
+		})
+
+		It("should use the chosen format", func() {
+			eIO.WriteFile(filepath.Join(project, ".nvmrc"), "6")
+
+			path, _ := New(&Args{
+				Language: "node",
+				Format:   "tool-versions",
+			}).LocalPath(project)
+
+			Expect(path).To(Equal(filepath.Join(project, ".tool-versions")))
+		})
+
+		It("should return an error for unknown format", func() {
+			_, err := New(&Args{
+				Language: "node",
+				Format:   "test",
+			}).LocalPath(project)
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("SystemBin", func() {
+		var (
+			home   string
+			system string
+			path   string
+		)
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-system-home")
+			system = filepath.Join(os.TempDir(), "eclectica-system-bin")
+			path = os.Getenv("PATH")
+
+			os.Setenv("EC_HOME", home)
+			os.MkdirAll(filepath.Join(home, "bin"), 0755)
+			os.MkdirAll(system, 0755)
+
+			ioutil.WriteFile(filepath.Join(home, "bin", "node"), []byte(""), 0755)
+
+			os.Setenv("PATH", filepath.Join(home, "bin")+":"+system)
+		})
+
+		AfterEach(func() {
+			os.Setenv("PATH", path)
+			os.Unsetenv("EC_HOME")
+			os.RemoveAll(home)
+			os.RemoveAll(system)
+		})
+
+		It("should skip eclectica folders", func() {
+			ioutil.WriteFile(filepath.Join(system, "node"), []byte(""), 0755)
+
+			result, err := SystemBin("node")
+
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(filepath.Join(system, "node")))
+		})
+
+		It("should skip links to the eclectica binaries", func() {
+			os.Symlink(filepath.Join(home, "bin", "node"), filepath.Join(system, "node"))
+
+			_, err := SystemBin("node")
+
+			Expect(err.Error()).To(Equal(`"node" is not installed in the system`))
+		})
+	})
+
 	Describe("Info", func() {
 		var guard *monkey.PatchGuard
 
@@ -633,10 +972,7 @@ var _ = Describe("plugins", func() {
 		It("should augment output from plugin `Info` method", func() {
 			info, _ := plugin.Info()
 
-			tmpDir := os.TempDir()
-			if runtime.GOOS == "linux" {
-				tmpDir += "/"
-			}
+			tmpDir := filepath.Join(variables.Support(), "tmp") + "/"
 
 			Expect(info["name"]).To(Equal("node"))
 			Expect(info["version"]).To(Equal("5.0.0"))
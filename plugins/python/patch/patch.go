@@ -16,6 +16,7 @@ import (
 
 	"github.com/markelog/eclectica/console"
 	eIO "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
 )
@@ -31,12 +32,12 @@ var (
 // URLs returns list of all needed patch urls
 func URLs(version string) (urls []string, err error) {
 	var (
-		unsemVersion = versions.Unsemverify(version)
-		link         = fmt.Sprintf("%s/%s/Python-%s", Link, unsemVersion, unsemVersion)
-		rawLink      = fmt.Sprintf("%s/%s/Python-%s", RawLink, unsemVersion, unsemVersion)
+		pythonVersion = versions.Format("python", version)
+		link          = fmt.Sprintf("%s/%s/Python-%s", Link, pythonVersion, pythonVersion)
+		rawLink       = fmt.Sprintf("%s/%s/Python-%s", RawLink, pythonVersion, pythonVersion)
 	)
 
-	doc, err := goquery.NewDocument(link)
+	doc, err := request.Document(link)
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
 			return nil, errors.New(variables.ConnectionError)
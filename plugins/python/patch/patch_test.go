@@ -65,5 +65,12 @@ var _ = Describe("patch", func() {
 			Expect(urls[3]).Should(ContainSubstring("2.7/Python-2.7"))
 			Expect(urls[3]).Should(ContainSubstring(patch.RawLink))
 		})
+
+		It("should spell version the way python does", func() {
+			urls, err := patch.URLs("3.6.0")
+
+			Expect(err).To(BeNil())
+			Expect(urls[0]).Should(ContainSubstring("3.6.0/Python-3.6.0"))
+		})
 	})
 })
@@ -12,20 +12,16 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/PuerkitoBio/goquery"
-	"github.com/blang/semver"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
-	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/eclectica/console"
 	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/plugins/python/patch"
+	"github.com/markelog/eclectica/request"
 	eStrings "github.com/markelog/eclectica/strings"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
@@ -43,10 +39,8 @@ var (
 	baseURL = "https://bootstrap.pypa.io/"
 	pipURL  = baseURL + pipName
 
-	// Hats off to inconsistent python developers
-	noNilVersions, _ = semver.Make("3.3.0")
 	// When pip began to be available with binaries
-	pipAvailable, _ = semver.Make("2.7.9")
+	pipAvailable, _ = versions.Parse("2.7.9")
 
 	bins = []string{"2to3", "idle", "pydoc", "python", "python-config", "pip", "easy_install"}
 	dots = []string{".python-version"}
@@ -142,23 +136,14 @@ func (python Python) PostInstall() (err error) {
 // Info provides all the info needed for installation of the plugin
 func (python Python) Info() map[string]string {
 	var (
-		result    = make(map[string]string)
-		version   = python.Version
-		chosen, _ = semver.Make(python.Version)
-
-		patch = strconv.Itoa(int(chosen.Patch))
-		minor = strconv.Itoa(int(chosen.Minor))
-		major = strconv.Itoa(int(chosen.Major))
-
-		urlPart = major + "." + minor + "." + patch
+		result  = make(map[string]string)
+		version = versions.Format("python", python.Version)
+		urlPart = version
 	)
 
-	// Hats off to inconsistent python developers
-	if chosen.LT(noNilVersions) {
-		version = versions.Unsemverify(version)
-		version = strings.Replace(version, "-", "", 1)
-
-		urlPart = versions.Unsemverify(urlPart)
+	// Folders on the server are named after the releases
+	if chosen, err := versions.Parse(python.Version); err == nil {
+		urlPart = chosen.Release().Format("python")
 	}
 
 	// Python 2.0 has different format and it's not supported
@@ -167,7 +152,7 @@ func (python Python) Info() map[string]string {
 	result["filename"] = "Python-" + version
 	result["url"] = fmt.Sprintf(
 		"%s/%s/%s.%s",
-		remoteVersion,
+		variables.Mirror("python").Download(remoteVersion),
 		urlPart,
 		result["filename"],
 		result["extension"],
@@ -190,7 +175,7 @@ func (python Python) Dots() []string {
 
 // ListRemote returns list of the all available remote versions
 func (python Python) ListRemote() (result []string, err error) {
-	doc, err := goquery.NewDocument(VersionLink)
+	doc, err := request.Document(variables.Mirror("python").Listing(VersionLink))
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
@@ -217,9 +202,9 @@ func (python Python) ListRemote() (result []string, err error) {
 
 	// Remove < 2.7 versions and "Pre" versions
 	for _, element := range tmp {
-		smr, _ := semver.Make(versions.Semverify(element))
+		smr, errParse := versions.Parse(element)
 
-		if len(smr.Pre) > 0 {
+		if errParse != nil || smr.IsPrerelease() {
 			continue
 		}
 		if smr.Major < 2 {
@@ -278,7 +263,7 @@ func (python Python) prepare() (err error) {
 	// Ignore touch errors since newest python makefile doesn't have this task
 	python.touch()
 
-	cmd, stderr, stdout, err := python.getCmd("make", "-j")
+	cmd, stderr, stdout, err := python.getCmd("make", fmt.Sprintf("-j%d", variables.Jobs()))
 	if err != nil {
 		return err
 	}
@@ -451,8 +436,8 @@ func (python Python) externals() (err error) {
 
 // Since python 3.x versions are naming their binaries with 3 affix
 func (python Python) renameLinks() (err error) {
-	chosen, _ := semver.Make(python.Version)
-	if chosen.Major < 3 {
+	chosen, err := versions.Parse(python.Version)
+	if err != nil || chosen.Major < 3 {
 		return nil
 	}
 
@@ -499,55 +484,37 @@ func (python Python) downloadExternals() (err error) {
 		urls = append(urls, pipURL)
 	}
 
-	respch, err := grab.GetBatch(len(urls), path, urls...)
-	if err != nil {
-		return errors.New(err)
-	}
-
-	// Start a ticker to update progress every 200ms
-	ticker := time.NewTicker(200 * time.Millisecond)
-
-	// Monitor downloads
-	completed := 0
-	responses := make([]*grab.Response, 0)
-	for completed < len(urls) {
-		select {
-		case resp := <-respch:
-
-			// When done
-			if resp != nil {
-				responses = append(responses, resp)
-			}
-
-		case <-ticker.C:
-
-			// Update completed downloads
-			for i, resp := range responses {
-				if resp != nil && resp.IsComplete() {
+	responses := []*request.Response{}
+	for _, url := range urls {
+		response, errDownload := request.Download(url, filepath.Join(path, filepath.Base(url)))
+		if errDownload != nil {
+			err = errDownload
+			break
+		}
 
-					if resp.Error != nil {
-						err = resp.Error
-						return errors.New(err)
-					}
+		responses = append(responses, response)
+	}
 
-					// Mark completed
-					responses[i] = nil
-					completed++
-				}
-			}
+	// Wait for every started download, so none of them
+	// would be left writing to the folder after we return
+	for _, response := range responses {
+		errWait := response.Wait()
+		if err == nil {
+			err = errWait
 		}
 	}
 
-	ticker.Stop()
-
 	return
 }
 
 // Since 2.7.9 versions we can simplify pip and setuptools install
 func hasTools(version string) bool {
-	semverVersion, _ := semver.Make(version)
+	parsed, err := versions.Parse(version)
+	if err != nil {
+		return false
+	}
 
-	return semverVersion.Compare(pipAvailable) != -1
+	return parsed.LessThan(pipAvailable) == false
 }
 
 func checkErrors(out []byte) (err error) {
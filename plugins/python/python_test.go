@@ -12,6 +12,7 @@ import (
 
 	eio "github.com/markelog/eclectica/io"
 	. "github.com/markelog/eclectica/plugins/python"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -75,10 +76,13 @@ var _ = Describe("python", func() {
 	})
 
 	Describe("Info", func() {
+		transport := request.Transport
+
 		BeforeEach(func() {
 			content := eio.Read("../../testdata/plugins/python/index.html")
 
 			httpmock.Activate()
+			request.Transport = httpmock.DefaultTransport
 
 			httpmock.RegisterResponder(
 				"GET",
@@ -94,7 +98,8 @@ var _ = Describe("python", func() {
 		})
 
 		AfterEach(func() {
-			defer httpmock.DeactivateAndReset()
+			httpmock.DeactivateAndReset()
+			request.Transport = transport
 		})
 
 		It("should get info about rc version", func() {
@@ -10,12 +10,12 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/chuckpreslar/emission"
 	"github.com/go-errors/errors"
 
 	"github.com/markelog/eclectica/plugins/ruby/base"
 	"github.com/markelog/eclectica/plugins/ruby/rvm"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -82,15 +82,18 @@ func (ruby Ruby) Info() map[string]string {
 
 	result["filename"] = fmt.Sprintf("ruby-%s", ruby.Version)
 	result["extension"] = "tar.bz2"
-	result["url"] = fmt.Sprintf("%s/%s.%s", rvm.GetURL(VersionLink), result["filename"], result["extension"])
+	link := rvm.GetURL(variables.Mirror("rvm").Download(VersionLink))
+
+	result["url"] = fmt.Sprintf("%s/%s.%s", link, result["filename"], result["extension"])
 
 	return result
 }
 
 // ListRemote returns list of the all available remote versions
 func (ruby Ruby) ListRemote() ([]string, error) {
-	url := rvm.GetURL(VersionLink)
-	doc, err := goquery.NewDocument(url)
+	mirror := variables.Mirror("rvm")
+	url := mirror.Listing(rvm.GetURL(mirror.Download(VersionLink)))
+	doc, err := request.Document(url)
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
@@ -7,10 +7,10 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/markelog/archive"
-	"gopkg.in/cavaliercoder/grab.v1"
 
 	"github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins/ruby/rvm"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -44,10 +44,16 @@ func binRuby() (bin string, err error) {
 		return
 	}
 
-	url := fmt.Sprintf("%s/ruby-2.1.5.tar.bz2", rvm.GetURL(versionLink))
-	_, err = grab.Get(tmp, url)
+	link := rvm.GetURL(variables.Mirror("rvm").Download(versionLink))
+	url := fmt.Sprintf("%s/ruby-2.1.5.tar.bz2", link)
+
+	response, err := request.Download(url, archived)
+	if err != nil {
+		return
+	}
+
+	err = response.Wait()
 	if err != nil {
-		err = errors.New(err)
 		return
 	}
 
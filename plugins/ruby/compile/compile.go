@@ -23,6 +23,7 @@ import (
 	"github.com/markelog/eclectica/console"
 	eIO "github.com/markelog/eclectica/io"
 	"github.com/markelog/eclectica/plugins/ruby/base"
+	"github.com/markelog/eclectica/request"
 	eStrings "github.com/markelog/eclectica/strings"
 	"github.com/markelog/eclectica/variables"
 	"github.com/markelog/eclectica/versions"
@@ -120,14 +121,16 @@ func (ruby Ruby) Info() map[string]string {
 	result := make(map[string]string)
 
 	result["filename"] = fmt.Sprintf("ruby-%s", remoteMap(ruby.Version))
-	result["url"] = fmt.Sprintf("%s/%s.tar.gz", VersionLink, result["filename"])
+	link := variables.Mirror("ruby").Download(VersionLink)
+	result["url"] = fmt.Sprintf("%s/%s.tar.gz", link, result["filename"])
 
 	return result
 }
 
 // ListRemote returns list of the all available remote versions
 func (ruby Ruby) ListRemote() ([]string, error) {
-	doc, err := goquery.NewDocument(VersionLink)
+	mirror := variables.Mirror("ruby")
+	doc, err := request.Document(mirror.Listing(mirror.Download(VersionLink)))
 
 	if err != nil {
 		if _, ok := err.(net.Error); ok {
@@ -187,7 +190,7 @@ func (ruby Ruby) configure() (err error) {
 func (ruby Ruby) prepare() (err error) {
 	ruby.Emitter.Emit("prepare")
 
-	cmd, stderr, stdout, err := ruby.getCmd("make", "-j")
+	cmd, stderr, stdout, err := ruby.getCmd("make", fmt.Sprintf("-j%d", variables.Jobs()))
 	if err != nil {
 		return
 	}
@@ -209,7 +212,7 @@ func (ruby Ruby) prepare() (err error) {
 func (ruby Ruby) install() (err error) {
 	ruby.Emitter.Emit("install")
 
-	cmd, stderr, stdout, err := ruby.getCmd("make", "install", "-j")
+	cmd, stderr, stdout, err := ruby.getCmd("make", "install", fmt.Sprintf("-j%d", variables.Jobs()))
 	if err != nil {
 		return
 	}
@@ -298,9 +301,12 @@ func (ruby Ruby) getCmd(args ...string) (
 }
 
 func remoteMap(version string) string {
-	proper := versions.Semverify(version)
+	parsed, err := versions.Parse(version)
+	if err != nil {
+		return version
+	}
 
-	if name, ok := remoteVersions[proper]; ok {
+	if name, ok := remoteVersions[parsed.String()]; ok {
 		return name
 	}
 
@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/blang/semver"
 	"github.com/markelog/eclectica/versions"
 	"github.com/markelog/release"
 )
@@ -15,7 +14,7 @@ import (
 var (
 
 	// Right now lowest possible version on rvm is for "10.12"
-	min, _ = semver.Parse("10.12.0")
+	min, _ = versions.Parse("10.12.0")
 )
 
 // RemoveArtefacts removes RVM artefacts (ignore errors)
@@ -40,11 +39,11 @@ func RemoveArtefacts(base string) (err error) {
 // GetURL returns OS version, type and name
 func GetURL(versionLink string) string {
 	typa, _, version := release.All()
-	floatVersion, _ := semver.Parse(versions.Semverify(version))
+	osVersion, err := versions.Parse(version)
 	arch := "x86_64"
 
-	if floatVersion.GT(min) {
-		version = fmt.Sprintf("%v", versions.Unsemverify(min.String()))
+	if err == nil && min.LessThan(osVersion) {
+		version = min.String()
 	}
 
 	versions := strings.Split(version, ".")
@@ -17,15 +17,13 @@ import (
 	"github.com/markelog/eclectica/pkg"
 	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
 	// VersionLink is the URL link from which we can get all possible versions
 	VersionLink = "https://static.rust-lang.org/dist"
 
-	versionPattern = "\\d+\\.\\d+(?:\\.\\d+)?(?:-(alpha|beta)(?:\\.\\d*)?)?"
-	listLink       = "https://static.rust-lang.org/dist/index.txt"
-
 	bins = []string{"cargo", "rust-gdb", "rustc", "rustdoc"}
 	dots = []string{".rust-version"}
 )
@@ -82,7 +80,8 @@ func (rust Rust) Info() map[string]string {
 		result      = make(map[string]string)
 		platform, _ = getPlatform()
 		filename    = fmt.Sprintf("rust-%s-%s", rust.Version, platform)
-		sourcesURL  = fmt.Sprintf("%s/%s", VersionLink, filename)
+		link        = variables.Mirror("rust").Download(VersionLink)
+		sourcesURL  = fmt.Sprintf("%s/%s", link, filename)
 	)
 
 	result["filename"] = filename
@@ -105,7 +104,10 @@ func (rust Rust) Dots() []string {
 
 // ListRemote returns list of the all available remote versions
 func (rust Rust) ListRemote() ([]string, error) {
-	body, err := request.Body(listLink)
+	mirror := variables.Mirror("rust")
+	listLink := fmt.Sprintf("%s/index.txt", mirror.Download(VersionLink))
+
+	body, err := request.Body(mirror.Listing(listLink))
 
 	if err != nil {
 		return []string{}, err
@@ -114,39 +116,23 @@ func (rust Rust) ListRemote() ([]string, error) {
 	return getVersions(body)
 }
 
-func getFullPattern() (string, error) {
-	platform, err := getPlatform()
-
-	if err != nil {
-		return "", err
-	}
-
-	result := "/dist/rust-" + versionPattern + "-" + platform + ".tar.gz,"
-
-	return result, nil
-}
-
 func getVersions(list string) ([]string, error) {
-	fullPattern, err := getFullPattern()
+	platform, err := getPlatform()
 	result := []string{}
 
 	if err != nil {
 		return result, err
 	}
 
-	fullUrlsPattern := regexp.MustCompile(fullPattern)
-
-	fullUrlsTmp := fullUrlsPattern.FindAllStringSubmatch(list, -1)
-	var fullUrls []string
-
-	// Flatten them out
-	for _, element := range fullUrlsTmp {
-		fullUrls = append(fullUrls, element[0])
-	}
+	// Channel archives, like "rust-nightly-<platform>.tar.gz", are not versions
+	archive := regexp.MustCompile(
+		"/dist/rust-(\\S+?)-" + regexp.QuoteMeta(platform) + "\\.tar\\.gz,",
+	)
 
-	vp := regexp.MustCompile(versionPattern)
-	for _, element := range fullUrls {
-		result = append(result, vp.FindAllStringSubmatch(element, 1)[0][0])
+	for _, match := range archive.FindAllStringSubmatch(list, -1) {
+		if _, errParse := versions.Parse(match[1]); errParse == nil {
+			result = append(result, match[1])
+		}
 	}
 
 	return result, nil
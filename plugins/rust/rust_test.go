@@ -2,11 +2,13 @@ package rust_test
 
 import (
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -17,6 +19,7 @@ import (
 	. "github.com/markelog/eclectica/plugins/rust"
 
 	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/request"
 	"github.com/markelog/eclectica/variables"
 )
 
@@ -75,8 +78,19 @@ var _ = Describe("rust", func() {
 
 	Describe("ListRemote", func() {
 		Describe("fail", func() {
+			var (
+				backoff   time.Duration
+				transport http.RoundTripper
+			)
+
 			BeforeEach(func() {
+				backoff = request.Backoff
+				request.Backoff = 0
+
+				transport = request.Transport
+
 				httpmock.Activate()
+				request.Transport = httpmock.DefaultTransport
 
 				httpmock.RegisterResponder(
 					"GET",
@@ -86,21 +100,27 @@ var _ = Describe("rust", func() {
 			})
 
 			AfterEach(func() {
-				defer httpmock.DeactivateAndReset()
+				request.Backoff = backoff
+
+				httpmock.DeactivateAndReset()
+				request.Transport = transport
 			})
 
 			It("should return an error", func() {
 				remotes, err = rust.ListRemote()
 
-				Expect(err).Should(MatchError(variables.ConnectionError))
+				Expect(err.Error()).To(ContainSubstring("Server error"))
 			})
 		})
 
 		Describe("success", func() {
+			transport := request.Transport
+
 			BeforeEach(func() {
 				content := Read("../../testdata/plugins/rust/dist.txt")
 
 				httpmock.Activate()
+				request.Transport = httpmock.DefaultTransport
 
 				httpmock.RegisterResponder(
 					"GET",
@@ -110,7 +130,8 @@ var _ = Describe("rust", func() {
 			})
 
 			AfterEach(func() {
-				defer httpmock.DeactivateAndReset()
+				httpmock.DeactivateAndReset()
+				request.Transport = transport
 			})
 
 			BeforeEach(func() {
@@ -0,0 +1,46 @@
+// Package project provides ways to find out which
+// languages and versions are required by the project
+package project
+
+import (
+	"github.com/markelog/eclectica/plugins"
+)
+
+// Version essential struct
+type Version struct {
+	Language string
+	Version  string
+
+	// Path to the dot file where version was defined
+	Path string
+}
+
+// Versions collects versions of all supported languages defined by the dot files
+// for the provided (or current) path, going up the filesystem tree
+func Versions(args ...interface{}) (result []*Version, err error) {
+	result = []*Version{}
+
+	for _, language := range plugins.Plugins {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		version, path, errVersion := plugin.LocalVersion(args...)
+		if errVersion != nil {
+			return nil, errVersion
+		}
+
+		// Nothing was defined for this language
+		if version == "current" {
+			continue
+		}
+
+		result = append(result, &Version{
+			Language: language,
+			Version:  version,
+			Path:     path,
+		})
+	}
+
+	return
+}
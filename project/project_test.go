@@ -0,0 +1,40 @@
+package project_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/project"
+)
+
+var _ = Describe("project", func() {
+	Describe("Versions", func() {
+		var (
+			result []*Version
+			err    error
+		)
+
+		BeforeEach(func() {
+			path, _ := filepath.Abs("../testdata/project")
+			result, err = Versions(path)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+		})
+
+		It("should collect versions from dot files and multi-language file", func() {
+			Expect(len(result)).To(Equal(2))
+
+			Expect(result[0].Language).To(Equal("node"))
+			Expect(result[0].Version).To(Equal("8.9.4"))
+			Expect(result[0].Path).To(ContainSubstring("project/.tool-versions"))
+
+			Expect(result[1].Language).To(Equal("go"))
+			Expect(result[1].Version).To(Equal("1.9.2"))
+			Expect(result[1].Path).To(ContainSubstring("project/.go-version"))
+		})
+	})
+})
@@ -0,0 +1,331 @@
+// Package prune finds installed versions which are not used anymore
+// and the leftovers of the installations, so disk space could be reclaimed
+package prune
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/outdated"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+	"github.com/markelog/eclectica/versions"
+)
+
+// Version which archive path is used to learn names of the archives
+const sample = "9.9.9"
+
+// Kinds of the removed items
+const (
+	Version   = "version"
+	Archive   = "archive"
+	Workspace = "workspace"
+)
+
+// Rules define which versions are kept besides the current ones
+type Rules struct {
+
+	// Keep is how many newest versions are kept for every major, zero keeps none of them
+	Keep int
+
+	// KeepAliases keeps versions the aliases point to
+	KeepAliases bool
+
+	// Roots are the folders with projects, versions pinned by them are kept
+	Roots []string
+
+	// Force allows to prune in the system mode, where versions
+	// selected by other users can't be known
+	Force bool
+}
+
+// Item is the version folder, downloaded archive or the build workspace which could be removed
+type Item struct {
+	Kind     string
+	Language string
+	Version  string
+	Path     string
+	Size     int64
+}
+
+// Plan finds unused versions of all languages and leftovers of their installations
+func Plan(rules *Rules) (result []*Item, err error) {
+	result = []*Item{}
+
+	if variables.IsSystem() && rules.Force == false {
+		return nil, errors.New(
+			"Languages are shared between the users in the system mode, " +
+				"versions used by others might be removed, use \"--force\" to prune anyway",
+		)
+	}
+
+	pins, err := pinned(rules.Roots)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range plugins.Plugins {
+		plugin := plugins.New(&plugins.Args{
+			Language: language,
+		})
+
+		installed := plugin.List()
+		used := append([]string{plugin.Current()}, pins[language]...)
+
+		// Linked versions are not ours to remove
+		for _, version := range installed {
+			if variables.LinkTarget(language, version) != "" {
+				used = append(used, version)
+			}
+		}
+
+		if rules.KeepAliases {
+			defined, _ := aliases.List(language)
+
+			for _, version := range defined {
+				used = append(used, version)
+			}
+		}
+
+		for _, version := range Select(installed, resolve(used, installed), rules.Keep) {
+			item, errItem := newItem(Version, language, version, variables.Path(language, version))
+			if errItem != nil {
+				return nil, errItem
+			}
+
+			result = append(result, item)
+		}
+
+		leftovers, errLeftovers := leftovers(language)
+		if errLeftovers != nil {
+			return nil, errLeftovers
+		}
+
+		result = append(result, leftovers...)
+	}
+
+	return
+}
+
+// Select gets installed versions which are neither used,
+// nor among the newest ones which are kept for every major
+func Select(installed, used []string, keep int) (result []string) {
+	var (
+		parsed = map[string]*versions.Version{}
+		counts = map[int]int{}
+		sorted = []string{}
+	)
+
+	for _, version := range installed {
+		// Versions we don't understand are not touched
+		if value, err := versions.Parse(version); err == nil {
+			parsed[version] = value
+			sorted = append(sorted, version)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return parsed[sorted[j]].LessThan(parsed[sorted[i]])
+	})
+
+	for _, version := range sorted {
+		major := parsed[version].Major
+
+		if counts[major] < keep {
+			counts[major]++
+			continue
+		}
+
+		if isOneOf(version, used) {
+			continue
+		}
+
+		result = append(result, version)
+	}
+
+	return
+}
+
+// Remove removes the item, versions are removed the same way as with "ec rm"
+func (item *Item) Remove() error {
+	if item.Kind == Version {
+		return plugins.New(&plugins.Args{
+			Language: item.Language,
+			Version:  item.Version,
+		}).Remove()
+	}
+
+	err := os.RemoveAll(item.Path)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// pinned gets versions pinned by the projects in the provided folders
+func pinned(roots []string) (map[string][]string, error) {
+	result := map[string][]string{}
+
+	for _, root := range roots {
+		if _, err := os.Stat(root); err != nil {
+			return nil, errors.New("Project root \"" + root + "\" does not exist")
+		}
+
+		pins, err := outdated.Scan(root)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pin := range pins {
+
+			// Broken dot files are reported by "ec outdated --scan"
+			if pin.Error != nil {
+				continue
+			}
+
+			result[pin.Language] = append(result[pin.Language], pin.Version)
+		}
+	}
+
+	return result, nil
+}
+
+// resolve resolves masks to the newest installed versions,
+// spelled the same way as their folders are
+func resolve(used, installed []string) (result []string) {
+	for _, version := range used {
+		if versions.IsPartial(version) {
+			var err error
+
+			version, err = versions.Highest(versions.Match(version, installed))
+			if err != nil {
+				continue
+			}
+		}
+
+		result = append(result, version)
+	}
+
+	return
+}
+
+// leftovers gets downloaded archives and build workspaces of the language, these
+// are not needed after installation, including the ones of already removed versions
+func leftovers(language string) (result []*Item, err error) {
+	archives, err := archives(language)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := workspaces(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(archives, workspaces...), nil
+}
+
+// archives finds downloaded archives of any version of the language,
+// their names are learned from the archive path of the sample version
+func archives(language string) (result []*Item, err error) {
+	path := plugins.New(&plugins.Args{
+		Language: language,
+		Version:  sample,
+	}).ArchivePath()
+
+	if strings.Contains(path, sample) == false {
+		return
+	}
+
+	var (
+		index  = strings.Index(path, sample)
+		prefix = path[:index]
+		suffix = path[index+len(sample):]
+	)
+
+	matches, err := filepath.Glob(prefix + "*" + suffix)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, match := range matches {
+		version := strings.TrimSuffix(strings.TrimPrefix(match, prefix), suffix)
+
+		// Glob might match more then the version, like the "rc" archives of other tools
+		if _, errParse := versions.Parse(version); errParse != nil {
+			continue
+		}
+
+		item, errItem := newItem(Archive, language, version, match)
+		if errItem != nil {
+			return nil, errItem
+		}
+
+		result = append(result, item)
+	}
+
+	return
+}
+
+// workspaces finds build workspaces of any version of the language
+func workspaces(language string) (result []*Item, err error) {
+	folders, err := ioutil.ReadDir(filepath.Join(variables.InstallPath(), language))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, folder := range folders {
+		if folder.IsDir() == false {
+			continue
+		}
+
+		version := folder.Name()
+
+		item, errItem := newItem(Workspace, language, version, variables.InstallLanguage(language, version))
+		if errItem != nil {
+			return nil, errItem
+		}
+
+		result = append(result, item)
+	}
+
+	return
+}
+
+// newItem creates item with the size of its path
+func newItem(kind, language, version, path string) (*Item, error) {
+	size, err := io.Size(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Item{
+		Kind:     kind,
+		Language: language,
+		Version:  version,
+		Path:     path,
+		Size:     size,
+	}, nil
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, elem := range names {
+		if elem == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,237 @@
+package prune_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/aliases"
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	. "github.com/markelog/eclectica/prune"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("prune", func() {
+	Describe("Select", func() {
+		installed := []string{"0.10.48", "6.11.5", "6.14.4", "8.9.0", "8.9.4", "8.11.1"}
+
+		It("should select everything which is not used", func() {
+			result := Select(installed, []string{"8.9.4"}, 0)
+
+			Expect(result).To(Equal([]string{"8.11.1", "8.9.0", "6.14.4", "6.11.5", "0.10.48"}))
+		})
+
+		It("should keep newest versions for every major", func() {
+			result := Select(installed, []string{}, 1)
+
+			Expect(result).To(Equal([]string{"8.9.4", "8.9.0", "6.11.5"}))
+		})
+
+		It("should keep used versions along with the newest ones", func() {
+			result := Select(installed, []string{"8.9.0"}, 2)
+
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should not touch versions it doesn't understand", func() {
+			result := Select([]string{"8.9.0", "nightly"}, []string{}, 0)
+
+			Expect(result).To(Equal([]string{"8.9.0"}))
+		})
+	})
+
+	Describe("Plan", func() {
+		var (
+			home    string
+			project string
+		)
+
+		find := func(items []*Item, kind string) (result []string) {
+			for _, item := range items {
+				if item.Kind == kind {
+					result = append(result, item.Language+"@"+item.Version)
+				}
+			}
+
+			return
+		}
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-prune-home")
+			project = filepath.Join(os.TempDir(), "eclectica-prune-project")
+
+			os.Setenv("EC_HOME", home)
+
+			for _, version := range []string{"6.11.5", "6.14.4", "8.9.0", "8.9.4", "9.3.0"} {
+				os.MkdirAll(variables.Path("node", version), 0755)
+				variables.WriteVersion("node", version)
+			}
+
+			os.Symlink(variables.Path("node", "9.3.0"), variables.Path("node"))
+
+			os.MkdirAll(filepath.Join(project, "api"), 0755)
+			io.WriteFile(filepath.Join(project, "api", ".node-version"), "6")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("EC_HOME")
+			os.Unsetenv("EC_SYSTEM_ROOT")
+			os.RemoveAll(home)
+			os.RemoveAll(project)
+		})
+
+		It("should keep current version and versions pinned by the projects", func() {
+			items, err := Plan(&Rules{
+				Roots: []string{project},
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Version)).To(Equal([]string{"node@8.9.4", "node@8.9.0", "node@6.11.5"}))
+		})
+
+		It("should keep pinned versions which are not spelled as semver", func() {
+			for _, version := range []string{"1.9.4", "1.10"} {
+				os.MkdirAll(variables.Path("go", version), 0755)
+				variables.WriteVersion("go", version)
+			}
+
+			io.WriteFile(filepath.Join(project, "api", ".go-version"), "1.10")
+
+			items, err := Plan(&Rules{
+				Roots: []string{project},
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Version)).To(ContainElement("go@1.9.4"))
+			Expect(find(items, Version)).NotTo(ContainElement("go@1.10"))
+		})
+
+		It("should keep aliases", func() {
+			aliases.Set("node", "legacy", "8.9.0")
+
+			items, err := Plan(&Rules{
+				KeepAliases: true,
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Version)).To(Equal([]string{"node@8.9.4", "node@6.14.4", "node@6.11.5"}))
+		})
+
+		It("should keep newest versions for every major", func() {
+			items, err := Plan(&Rules{
+				Keep: 1,
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Version)).To(Equal([]string{"node@8.9.0", "node@6.11.5"}))
+		})
+
+		It("should find sizes of the versions", func() {
+			io.WriteFile(filepath.Join(variables.Path("node", "8.9.0"), "node"), "12345")
+
+			items, _ := Plan(&Rules{
+				Keep: 1,
+			})
+
+			Expect(items[0].Path).To(Equal(variables.Path("node", "8.9.0")))
+			Expect(items[0].Size).To(Equal(int64(10)))
+		})
+
+		It("should find archives and build workspaces", func() {
+			archive := plugins.New(&plugins.Args{
+				Language: "node",
+				Version:  "9.3.0",
+			}).ArchivePath()
+
+			os.MkdirAll(filepath.Dir(archive), 0755)
+			io.WriteFile(archive, "archive")
+
+			os.MkdirAll(variables.InstallLanguage("node", "8.9.0"), 0755)
+
+			items, err := Plan(&Rules{
+				Keep: 2,
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Archive)).To(Equal([]string{"node@9.3.0"}))
+			Expect(find(items, Workspace)).To(Equal([]string{"node@8.9.0"}))
+		})
+
+		It("should find archives and workspaces of removed versions", func() {
+			archive := plugins.New(&plugins.Args{
+				Language: "node",
+				Version:  "4.0.0",
+			}).ArchivePath()
+
+			os.MkdirAll(filepath.Dir(archive), 0755)
+			io.WriteFile(archive, "archive")
+			os.MkdirAll(variables.InstallLanguage("node", "5.0.0"), 0755)
+
+			items, err := Plan(&Rules{
+				Keep: 2,
+			})
+
+			Expect(err).To(BeNil())
+			Expect(find(items, Archive)).To(Equal([]string{"node@4.0.0"}))
+			Expect(find(items, Workspace)).To(Equal([]string{"node@5.0.0"}))
+		})
+
+		It("should keep linked versions", func() {
+			os.MkdirAll(variables.Links("node"), 0755)
+			io.WriteFile(filepath.Join(variables.Links("node"), "6.11.5"), "/opt/node")
+
+			items, _ := Plan(&Rules{})
+
+			Expect(find(items, Version)).NotTo(ContainElement("node@6.11.5"))
+		})
+
+		It("should refuse to prune in the system mode", func() {
+			os.Setenv("EC_SYSTEM_ROOT", home)
+
+			_, err := Plan(&Rules{})
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("--force"))
+		})
+
+		It("should return an error for nonexistent project root", func() {
+			_, err := Plan(&Rules{
+				Roots: []string{filepath.Join(project, "nope")},
+			})
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("does not exist"))
+		})
+	})
+
+	Describe("Remove", func() {
+		var home string
+
+		BeforeEach(func() {
+			home = filepath.Join(os.TempDir(), "eclectica-prune-home")
+
+			os.Setenv("EC_HOME", home)
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("EC_HOME")
+			os.RemoveAll(home)
+		})
+
+		It("should remove the workspace", func() {
+			path := variables.InstallLanguage("node", "8.9.0")
+			os.MkdirAll(path, 0755)
+
+			item := &Item{
+				Kind: Workspace,
+				Path: path,
+			}
+
+			Expect(item.Remove()).To(BeNil())
+			Expect(path).NotTo(BeADirectory())
+		})
+	})
+})
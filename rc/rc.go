@@ -2,10 +2,13 @@
 package rc
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 
@@ -17,144 +20,335 @@ const (
 	begin   = `#eclectica start`
 	end     = `#eclectica end`
 	command = `
-command -v ec > /dev/null && export PATH="$(ec path)"
+command -v ec > /dev/null && eval "$(ec init %s)"
+`
+	fishCommand = `
+if command -s ec > /dev/null
+    ec init fish | source
+end
 `
 )
 
 var (
-	reg = regexp.MustCompile(begin + "(?:[^\n]*\n+)+" + end)
+
+	// ProfileD is the rc file sourced for all users, it is used in the system mode
+	ProfileD = "/etc/profile.d/eclectica.sh"
+
+	reg = regexp.MustCompile(begin + "(?:[^\n]*\n+)+" + end + "\n?")
+
+	// Bash reads only the first existing one of these for the login shells
+	logins = []string{".bash_profile", ".bash_login", ".profile"}
+
 	rcs = map[string][]string{
 		"bash": {".bash_profile", ".bashrc", ".profile"},
 		"zsh":  {".zshrc"},
+		"fish": {".config/fish/conf.d/eclectica.fish"},
 	}
 )
 
 // Rc essential structure
 type Rc struct {
-	path string
+	path     string
+	isSystem bool
+}
+
+// Change is a modification of the rc file
+type Change struct {
+	Path   string
+	Before string
+	After  string
+
+	// File is created by eclectica, so it could be removed entirely
+	isOwned bool
 }
 
 // New returns new Rc struct
 func New() *Rc {
 	rc := &Rc{}
+
+	// Shared languages are set up for all users by the administrator
+	if variables.IsSystem() && os.Geteuid() == 0 {
+		rc.path = ProfileD
+		rc.isSystem = true
+
+		return rc
+	}
+
 	rc.path = rc.Find()
 
 	return rc
 }
 
-// getRcs gets rc instances
-func (rc *Rc) getRcs() (bashrc *Rc, bashProfile *Rc) {
-	pathsRc := filepath.Join(os.Getenv("HOME"), ".bashrc")
-	pathsProfile := filepath.Join(os.Getenv("HOME"), ".bash_profile")
+// content gets the data which is added to the rc file,
+// it also defines the shared root and relocated eclectica home if needed
+func (rc *Rc) content() string {
+	result := begin
 
-	bashrc = &Rc{
-		path: pathsRc,
+	if variables.IsSystem() {
+		result += rc.export("EC_SYSTEM_ROOT", variables.SystemRoot())
 	}
 
-	bashProfile = &Rc{
-		path: pathsProfile,
+	if rc.isSystem == false && variables.Base() != variables.DefaultBase() {
+		result += rc.export("EC_HOME", variables.Base())
 	}
 
-	return bashrc, bashProfile
+	if rc.isFish() {
+		return result + fishCommand + end
+	}
+
+	return result + fmt.Sprintf(command, rc.shell()) + end
 }
 
-// Add bash configs on Unix system
-// .bashrc works when you open new bash session (open terminal)
-// .bash_profile is executed when you login
-//
-// So in order for our env variables to be
-// consistently exposed we need to modify both of them
-func (rc *Rc) Add() error {
-	shell := variables.GetShellName()
+// shell gets name of the shell for which rc file is intended,
+// files for the POSIX shells are considered bash ones
+func (rc *Rc) shell() string {
+	if rc.isFish() {
+		return "fish"
+	}
+
+	if filepath.Base(rc.path) == ".zshrc" {
+		return "zsh"
+	}
 
-	if shell != "bash" {
-		return rc.add()
+	return "bash"
+}
+
+// export gets line which defines the environment variable
+func (rc *Rc) export(name, value string) string {
+	if rc.isFish() {
+		return "\nset -gx " + name + " \"" + value + "\""
 	}
 
-	bashrc, bashProfile := rc.getRcs()
+	return "\nexport " + name + "=\"" + value + "\""
+}
 
-	err := bashrc.add()
+// isFish checks if rc file is intended for the fish shell
+func (rc *Rc) isFish() bool {
+	return strings.HasSuffix(rc.path, ".fish")
+}
+
+// Add adds eclectica to the rc files or updates it there
+func (rc *Rc) Add() error {
+	changes, err := rc.AddChanges()
 	if err != nil {
 		return err
 	}
 
-	err = bashProfile.add()
+	return Apply(changes)
+}
+
+// Remove removes eclectica from the rc files
+func (rc *Rc) Remove() error {
+	changes, err := rc.RemoveChanges()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return Apply(changes)
 }
 
-// add helper method for Add()
-func (rc *Rc) add() (err error) {
-	if rc.Exists() {
-		return
-	}
+// AddChanges gets changes needed to add eclectica to the rc files,
+// already present eclectica data is replaced with the actual one
+func (rc *Rc) AddChanges() (changes []*Change, err error) {
+	for _, path := range rc.files() {
+		change, err := newChange(path)
+		if err != nil {
+			return nil, err
+		}
+
+		block := (&Rc{path: path, isSystem: rc.isSystem}).content() + "\n"
+
+		if location := reg.FindStringIndex(change.Before); location != nil {
+			change.After = change.Before[:location[0]] + block + change.Before[location[1]:]
+		} else {
+			if change.After != "" && strings.HasSuffix(change.After, "\n") == false {
+				change.After += "\n"
+			}
+
+			change.After += block
+		}
 
-	if _, err := os.Stat(rc.path); err != nil {
-		return io.WriteFile(rc.path, begin+command+end)
+		changes = append(changes, change)
 	}
 
-	return rc.append()
+	return
 }
 
-// append to an rc file
-func (rc *Rc) append() (err error) {
-	file, err := os.OpenFile(rc.path, os.O_APPEND|os.O_WRONLY, os.ModeAppend)
-	defer file.Close()
+// RemoveChanges gets changes needed to remove eclectica from the rc files
+func (rc *Rc) RemoveChanges() (changes []*Change, err error) {
+	for _, path := range rc.files() {
+		change, err := newChange(path)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return errors.New(err)
+		change.After = reg.ReplaceAllLiteralString(change.Before, "")
+		change.isOwned = path == ProfileD || (&Rc{path: path}).isFish()
+
+		changes = append(changes, change)
 	}
 
-	_, err = file.WriteString(begin + command + end)
-	if err != nil {
-		return errors.New(err)
+	return
+}
+
+// Apply applies the changes, original files are backed up beforehand
+func Apply(changes []*Change) error {
+	for _, change := range changes {
+		if change.Before == change.After {
+			continue
+		}
+
+		err := change.apply()
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// Remove bash configs on Unix system
-func (rc *Rc) Remove() error {
-	shell := variables.GetShellName()
+// Diff gets changed lines prefixed with "+" or "-" and two lines
+// of the context around them prefixed with the space
+func (change *Change) Diff() (result []string) {
+	var (
+		before  = lines(change.Before)
+		after   = lines(change.After)
+		context = 2
+	)
+
+	// Longest common subsequence of the lines
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
 
-	if shell != "bash" {
-		return rc.remove()
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
 	}
 
-	bashrc, bashProfile := rc.getRcs()
+	all := []string{}
+	i, j := 0, 0
+
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			all = append(all, " "+before[i])
+			i++
+			j++
+		case j < len(after) && (i == len(before) || common[i][j+1] >= common[i+1][j]):
+			all = append(all, "+"+after[j])
+			j++
+		default:
+			all = append(all, "-"+before[i])
+			i++
+		}
+	}
 
-	err := bashrc.remove()
-	if err != nil {
-		return err
+	// Leave only context around the changes
+	skipped := false
+	for index, line := range all {
+		if isNear(all, index, context) {
+			result = append(result, line)
+			skipped = false
+		} else if skipped == false {
+			result = append(result, " ...")
+			skipped = true
+		}
 	}
 
-	err = bashProfile.remove()
-	if err != nil {
-		return err
+	return
+}
+
+// isNear checks if there is changed line near provided index
+func isNear(all []string, index, context int) bool {
+	for i := index - context; i <= index+context; i++ {
+		if i >= 0 && i < len(all) && all[i][0] != ' ' {
+			return true
+		}
 	}
 
-	return nil
+	return false
+}
+
+// lines splits the content to the lines
+func lines(content string) []string {
+	if content == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// newChange creates change with the current content of the file
+func newChange(path string) (*Change, error) {
+	change := &Change{Path: path}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil && os.IsNotExist(err) == false {
+		return nil, errors.New(err)
+	}
+
+	change.Before = string(content)
+	change.After = change.Before
+
+	return change, nil
 }
 
-// add helper method for Remove()
-func (rc *Rc) remove() (err error) {
-	if rc.Exists() == false {
+// apply backs up the file and writes it in place,
+// so its mode, owner and symlinks are preserved
+func (change *Change) apply() (err error) {
+	stat, err := os.Stat(change.Path)
+
+	if os.IsNotExist(err) {
+		_, err = io.CreateDir(filepath.Dir(change.Path))
+		if err != nil {
+			return
+		}
+
+		err = ioutil.WriteFile(change.Path, []byte(change.After), 0644)
+		if err != nil {
+			return errors.New(err)
+		}
+
 		return
 	}
 
-	read, err := ioutil.ReadFile(rc.path)
 	if err != nil {
 		return errors.New(err)
 	}
 
-	replaced := reg.ReplaceAll(read, []byte(""))
+	backup := change.Path + ".eclectica-" + time.Now().Format("20060102-150405")
+	err = ioutil.WriteFile(backup, []byte(change.Before), stat.Mode().Perm())
+	if err != nil {
+		return errors.New(err)
+	}
 
-	err = ioutil.WriteFile(rc.path, replaced, 0)
+	if change.isOwned && strings.TrimSpace(change.After) == "" {
+		err = os.Remove(change.Path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		return
+	}
+
+	file, err := os.OpenFile(change.Path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(change.After)
 	if err != nil {
-		err = errors.New(err)
+		return errors.New(err)
 	}
 
 	return
@@ -170,11 +364,59 @@ func (rc *Rc) Exists() bool {
 	return reg.MatchString(string(contents))
 }
 
+// files gets rc files which the shell really reads.
+// Bash reads .bashrc for the interactive shells and the first existing
+// of the login files for the login ones, which might source .bashrc too
+func (rc *Rc) files() []string {
+	if rc.isSystem || variables.GetShellName() != "bash" {
+		return []string{rc.path}
+	}
+
+	var (
+		home   = os.Getenv("HOME")
+		bashrc = filepath.Join(home, ".bashrc")
+		login  = filepath.Join(home, logins[0])
+	)
+
+	for _, name := range logins {
+		path := filepath.Join(home, name)
+
+		if _, err := os.Stat(path); err == nil {
+			login = path
+			break
+		}
+	}
+
+	if sources(login, home) {
+		return []string{bashrc}
+	}
+
+	return []string{bashrc, login}
+}
+
+// sources checks if login file sources .bashrc
+func sources(path, home string) bool {
+	pattern := `(^|[;\s])(source|\.)\s+["']?(~|\$HOME|\$\{HOME\}|` +
+		regexp.QuoteMeta(home) + `)/\.bashrc`
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return regexp.MustCompile(pattern).Match(content)
+}
+
 // Find finds proper rc file
 func (rc *Rc) Find() string {
 	home := os.Getenv("HOME")
 	shell := variables.GetShellName()
 
+	// zsh might read its files from another folder
+	if shell == "zsh" && os.Getenv("ZDOTDIR") != "" {
+		home = os.Getenv("ZDOTDIR")
+	}
+
 	files, _ := ioutil.ReadDir(home)
 
 	for _, possibility := range rcs[shell] {
@@ -185,5 +427,10 @@ func (rc *Rc) Find() string {
 		}
 	}
 
+	// None of them exist yet, so it would be created
+	if possibilities, ok := rcs[shell]; ok {
+		return filepath.Join(home, possibilities[0])
+	}
+
 	return ""
 }
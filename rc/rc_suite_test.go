@@ -0,0 +1,13 @@
+package rc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rc Suite")
+}
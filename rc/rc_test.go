@@ -0,0 +1,160 @@
+package rc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/rc"
+)
+
+var _ = Describe("rc", func() {
+	var (
+		home  string
+		shell = os.Getenv("SHELL")
+		user  = os.Getenv("HOME")
+	)
+
+	BeforeEach(func() {
+		home, _ = ioutil.TempDir("", "eclectica-rc")
+		os.Setenv("HOME", home)
+	})
+
+	AfterEach(func() {
+		os.Setenv("HOME", user)
+		os.Setenv("SHELL", shell)
+		os.RemoveAll(home)
+	})
+
+	Describe("fish", func() {
+		var path string
+
+		BeforeEach(func() {
+			os.Setenv("SHELL", "/usr/bin/fish")
+			path = filepath.Join(home, ".config/fish/conf.d/eclectica.fish")
+		})
+
+		It("should add fish configuration", func() {
+			err := New().Add()
+			Expect(err).To(BeNil())
+
+			content, _ := ioutil.ReadFile(path)
+			Expect(string(content)).To(ContainSubstring("ec init fish | source"))
+			Expect(string(content)).NotTo(ContainSubstring("export"))
+		})
+
+		It("should remove fish configuration", func() {
+			New().Add()
+
+			err := New().Remove()
+			Expect(err).To(BeNil())
+
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).To(Equal(true))
+		})
+	})
+
+	Describe("zsh", func() {
+		It("should add and remove the configuration", func() {
+			os.Setenv("SHELL", "/bin/zsh")
+			path := filepath.Join(home, ".zshrc")
+			ioutil.WriteFile(path, []byte("alias ll='ls -l'\n"), 0644)
+
+			New().Add()
+
+			content, _ := ioutil.ReadFile(path)
+			Expect(string(content)).To(ContainSubstring(`eval "$(ec init zsh)"`))
+
+			New().Remove()
+
+			content, _ = ioutil.ReadFile(path)
+			Expect(string(content)).To(Equal("alias ll='ls -l'\n"))
+		})
+	})
+	Describe("bash", func() {
+		BeforeEach(func() {
+			os.Setenv("SHELL", "/bin/bash")
+		})
+
+		It("should use .profile if user relies on it", func() {
+			profile := filepath.Join(home, ".profile")
+			ioutil.WriteFile(profile, []byte("export A=1\n"), 0644)
+
+			New().Add()
+
+			content, _ := ioutil.ReadFile(profile)
+			Expect(string(content)).To(ContainSubstring("#eclectica start"))
+
+			_, err := os.Stat(filepath.Join(home, ".bash_profile"))
+			Expect(os.IsNotExist(err)).To(Equal(true))
+		})
+
+		It("should not change login file which sources .bashrc", func() {
+			profile := filepath.Join(home, ".bash_profile")
+			data := "if [ -f ~/.bashrc ]; then . ~/.bashrc; fi\n"
+			ioutil.WriteFile(profile, []byte(data), 0644)
+
+			New().Add()
+
+			content, _ := ioutil.ReadFile(profile)
+			Expect(string(content)).To(Equal(data))
+
+			content, _ = ioutil.ReadFile(filepath.Join(home, ".bashrc"))
+			Expect(string(content)).To(ContainSubstring("#eclectica start"))
+		})
+
+		It("should back up the file and preserve its mode", func() {
+			bashrc := filepath.Join(home, ".bashrc")
+			ioutil.WriteFile(bashrc, []byte("export A=1"), 0600)
+
+			New().Add()
+
+			stat, _ := os.Stat(bashrc)
+			Expect(stat.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			backups, _ := filepath.Glob(bashrc + ".eclectica-*")
+			Expect(backups).To(HaveLen(1))
+
+			content, _ := ioutil.ReadFile(backups[0])
+			Expect(string(content)).To(Equal("export A=1"))
+		})
+
+		It("should not change anything if eclectica is already there", func() {
+			New().Add()
+
+			changes, _ := New().AddChanges()
+			for _, change := range changes {
+				Expect(change.After).To(Equal(change.Before))
+			}
+		})
+	})
+
+	Describe("Diff", func() {
+		It("should show changed lines with the context", func() {
+			change := &Change{
+				Before: "1\n2\n3\n4\n5\n6\n",
+				After:  "1\n2\n3\n4\n5\nnew\n6\n",
+			}
+
+			Expect(change.Diff()).To(Equal([]string{
+				" ...",
+				" 4",
+				" 5",
+				"+new",
+				" 6",
+			}))
+		})
+
+		It("should show removed lines", func() {
+			change := &Change{
+				Before: "a\nb\n",
+				After:  "a\n",
+			}
+
+			Expect(change.Diff()).To(Equal([]string{" a", "-b"}))
+		})
+	})
+})
@@ -0,0 +1,359 @@
+package request
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	eIO "github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Response of the download which continues in the background
+type Response struct {
+	// URL from where file is downloaded
+	URL string
+
+	// Filename is the path where file is downloaded to
+	Filename string
+
+	// Size of the whole file in bytes, zero if unknown
+	Size uint64
+
+	// HTTPResponse is the first response of the server
+	HTTPResponse *http.Response
+
+	// Error which happened during the download,
+	// should be checked only after download is completed
+	Error error
+
+	transferred uint64
+	done        chan bool
+	once        *sync.Once
+}
+
+// IsComplete checks if download is finished either successfully or not
+func (response *Response) IsComplete() bool {
+	select {
+	case <-response.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until download is finished and returns its error
+func (response *Response) Wait() error {
+	<-response.done
+
+	return response.Error
+}
+
+// BytesTransferred returns size of the already downloaded part
+func (response *Response) BytesTransferred() uint64 {
+	return atomic.LoadUint64(&response.transferred)
+}
+
+// Progress returns ratio of the downloaded part to the whole file
+func (response *Response) Progress() float64 {
+	if response.Size == 0 {
+		return 0
+	}
+
+	return float64(response.BytesTransferred()) / float64(response.Size)
+}
+
+func (response *Response) finish(err error) {
+	response.once.Do(func() {
+		response.Error = err
+		close(response.done)
+	})
+}
+
+// Download starts downloading of the url to the provided path.
+// If the file is already partially there it continues from where it stopped
+// (if server supports range requests). Download is retried on failures
+// and continues in the background after the first successful response
+func Download(url, path string) (*Response, error) {
+	_, err := eIO.CreateDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		URL:      url,
+		Filename: path,
+		done:     make(chan bool),
+		once:     &sync.Once{},
+	}
+
+	var (
+		httpResponse *http.Response
+		cancel       context.CancelFunc
+		offset       uint64
+	)
+
+	err = retry(func() (errRequest error) {
+		httpResponse, cancel, offset, errRequest = response.request()
+		return
+	})
+	if err != nil {
+		return nil, Classify(err)
+	}
+
+	response.HTTPResponse = httpResponse
+
+	// Everything might be already downloaded
+	if httpResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		httpResponse.Body.Close()
+		cancel()
+
+		if isComplete(httpResponse, offset) {
+			response.Size = offset
+			response.finish(nil)
+
+			return response, nil
+		}
+
+		// Local file does not match the remote one, so start from the scratch
+		os.Remove(path)
+
+		err = retry(func() (errRequest error) {
+			httpResponse, cancel, offset, errRequest = response.request()
+			return
+		})
+		if err != nil {
+			return nil, Classify(err)
+		}
+
+		response.HTTPResponse = httpResponse
+	}
+
+	if httpResponse.ContentLength > 0 {
+		response.Size = offset + uint64(httpResponse.ContentLength)
+	}
+
+	// Create the file right away, so consumers could rely on its existence
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		httpResponse.Body.Close()
+		cancel()
+
+		return nil, errors.New(err)
+	}
+
+	file.Close()
+
+	go response.continues(httpResponse, cancel)
+
+	return response, nil
+}
+
+// File downloads the url to the provided path and waits until it is done
+func File(url, path string) error {
+	response, err := Download(url, path)
+	if err != nil {
+		return err
+	}
+
+	return response.Wait()
+}
+
+// request requests the rest of the file, starting from
+// the size of the already downloaded part
+func (response *Response) request() (
+	httpResponse *http.Response,
+	cancel context.CancelFunc,
+	offset uint64,
+	err error,
+) {
+	if info, errStat := os.Stat(response.Filename); errStat == nil {
+		offset = uint64(info.Size())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	request, err := http.NewRequest("GET", response.URL, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, 0, errors.New(err)
+	}
+
+	request = request.WithContext(ctx)
+
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatUint(offset, 10)+"-")
+	}
+
+	httpResponse, err = client.Do(request)
+	if err != nil {
+		cancel()
+		return nil, nil, 0, err
+	}
+
+	switch {
+
+	// Server continues from where we stopped
+	case httpResponse.StatusCode == http.StatusPartialContent:
+
+	// Server either doesn't support ranges or we start from the scratch
+	case httpResponse.StatusCode == http.StatusOK:
+		offset = 0
+
+	// We already have the whole file
+	case httpResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+
+	default:
+		httpResponse.Body.Close()
+		cancel()
+
+		return nil, nil, 0, &StatusError{
+			Code:   httpResponse.StatusCode,
+			Status: httpResponse.Status,
+			URL:    response.URL,
+		}
+	}
+
+	atomic.StoreUint64(&response.transferred, offset)
+
+	return httpResponse, cancel, offset, nil
+}
+
+// continues writes body of the response to the file and
+// re-requests the rest of it in case connection was lost
+func (response *Response) continues(httpResponse *http.Response, cancel context.CancelFunc) {
+	var (
+		err      error
+		delay    = Backoff
+		attempts = variables.Retries() + 1
+	)
+
+	for attempt := 1; ; attempt++ {
+		err = response.write(httpResponse, cancel)
+		if err == nil {
+			break
+		}
+
+		if attempt >= attempts {
+			break
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+
+		var offset uint64
+
+		err = retry(func() (errRequest error) {
+			httpResponse, cancel, offset, errRequest = response.request()
+			return
+		})
+		if err != nil {
+			break
+		}
+
+		// Server says we already have everything
+		if httpResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			httpResponse.Body.Close()
+			cancel()
+
+			if isComplete(httpResponse, offset) == false {
+				os.Remove(response.Filename)
+				err = errors.New("Downloaded file \"" + response.Filename + "\" does not match the one from \"" + response.URL + "\"")
+			}
+
+			break
+		}
+	}
+
+	response.finish(Classify(err))
+}
+
+// write writes body to the file, reading is cancelled
+// if there was no data from the server for too long
+func (response *Response) write(httpResponse *http.Response, cancel context.CancelFunc) (err error) {
+	defer cancel()
+	defer httpResponse.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if httpResponse.StatusCode == http.StatusOK {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(response.Filename, flags, 0644)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer file.Close()
+
+	var (
+		expired int32
+		timeout = variables.ReadTimeout()
+	)
+
+	timer := time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&expired, 1)
+		cancel()
+	})
+	defer timer.Stop()
+
+	buffer := make([]byte, 32*1024)
+
+	for {
+		read, errRead := httpResponse.Body.Read(buffer)
+
+		if read > 0 {
+			timer.Reset(timeout)
+
+			_, errWrite := file.Write(buffer[:read])
+			if errWrite != nil {
+				return errors.New(errWrite)
+			}
+
+			atomic.AddUint64(&response.transferred, uint64(read))
+		}
+
+		if errRead == io.EOF {
+			return nil
+		}
+
+		if errRead != nil && atomic.LoadInt32(&expired) == 1 {
+			return &TimeoutError{
+				Timeout: timeout,
+				URL:     response.URL,
+			}
+		}
+
+		if errRead != nil {
+			return errRead
+		}
+	}
+}
+
+// isComplete checks if "416 Range Not Satisfiable" response means
+// the local file is complete, i.e. its size is equal to the remote one
+func isComplete(httpResponse *http.Response, offset uint64) bool {
+	// Should be in the form of "bytes */<size>"
+	contentRange := httpResponse.Header.Get("Content-Range")
+
+	index := strings.LastIndex(contentRange, "/")
+	if index == -1 {
+		return false
+	}
+
+	size, err := strconv.ParseUint(contentRange[index+1:], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return size == offset
+}
@@ -2,29 +2,185 @@
 package request
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/go-errors/errors"
 
 	"github.com/markelog/eclectica/variables"
 )
 
 var (
-	client = &http.Client{}
+
+	// Backoff is the delay before the second attempt,
+	// it is doubled for every next one
+	Backoff = time.Second
+
+	// Transport sends the requests, tests could replace it with the mock
+	Transport http.RoundTripper = &timeouts{}
+
+	client = &http.Client{
+		Transport: &transport{},
+	}
 )
 
+// StatusError is returned when server responded with unexpected status
+type StatusError struct {
+	Code   int
+	Status string
+	URL    string
+}
+
+// Error returns error message depending on the status family
+func (err *StatusError) Error() string {
+	if err.Code >= 500 {
+		return fmt.Sprintf(
+			"Server error \"%s\" for \"%s\", try again later", err.Status, err.URL,
+		)
+	}
+
+	return fmt.Sprintf("Server responded with \"%s\" for \"%s\"", err.Status, err.URL)
+}
+
+// TimeoutError is returned when server stopped sending the data
+type TimeoutError struct {
+	Timeout time.Duration
+	URL     string
+}
+
+// Error returns error message with the timeout
+func (err *TimeoutError) Error() string {
+	return fmt.Sprintf("No data received for %s from \"%s\", try again later", err.Timeout, err.URL)
+}
+
+// transport adds mirror credentials to the requests sent by the Transport
+type transport struct{}
+
+// RoundTrip executes a single HTTP transaction
+func (transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return Transport.RoundTrip(authorize(request))
+}
+
+// timeouts is the default transport with the configured timeouts,
+// it is recreated if the settings were changed
+type timeouts struct {
+	sync.Mutex
+
+	connect time.Duration
+	read    time.Duration
+	current *http.Transport
+}
+
+// RoundTrip executes a single HTTP transaction
+func (timeouts *timeouts) RoundTrip(request *http.Request) (*http.Response, error) {
+	return timeouts.get().RoundTrip(request)
+}
+
+// get gets transport for the current timeouts
+func (timeouts *timeouts) get() *http.Transport {
+	timeouts.Lock()
+	defer timeouts.Unlock()
+
+	connect := variables.ConnectTimeout()
+	read := variables.ReadTimeout()
+
+	if timeouts.current != nil && timeouts.connect == connect && timeouts.read == read {
+		return timeouts.current
+	}
+
+	timeouts.connect = connect
+	timeouts.read = read
+	timeouts.current = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   connect,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   connect,
+		ResponseHeaderTimeout: read,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	return timeouts.current
+}
+
+// authorize adds credentials of the mirror if request is pointed to it,
+// redirects to other hosts would not get them
+func authorize(request *http.Request) *http.Request {
+	if request.Header.Get("Authorization") != "" {
+		return request
+	}
+
+	link := request.URL.String()
+
+	for _, mirror := range variables.Mirrors() {
+		if mirror.HasCredentials() == false {
+			continue
+		}
+
+		if isWithin(link, mirror.URL) == false && isWithin(link, mirror.List) == false {
+			continue
+		}
+
+		// Request should not be modified by the transport
+		request = request.Clone(request.Context())
+
+		if mirror.Token != "" {
+			request.Header.Set("Authorization", "Bearer "+mirror.Token)
+		} else {
+			request.SetBasicAuth(mirror.Username, mirror.Password)
+		}
+
+		return request
+	}
+
+	return request
+}
+
+// isWithin checks if link is located under the base one
+func isWithin(link, base string) bool {
+	if base == "" {
+		return false
+	}
+
+	return link == base || strings.HasPrefix(link, base+"/")
+}
+
+// Document gets HTML document from provided url string
+func Document(url string) (*goquery.Document, error) {
+	response, err := Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	doc, err := goquery.NewDocumentFromReader(response.Body)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return doc, nil
+}
+
 // Body gets body response from provided url string
 func Body(url string) (string, error) {
-	response, err := client.Get(url)
+	response, err := Get(url)
 	if err != nil {
 		return "", err
 	}
 
-	if response.StatusCode != 200 {
-		return "", errors.New(variables.ConnectionError)
-	}
-
 	defer response.Body.Close()
 	contents, err := ioutil.ReadAll(response.Body)
 
@@ -35,3 +191,149 @@ func Body(url string) (string, error) {
 
 	return string(contents), nil
 }
+
+// Get requests provided url, request is retried in case of
+// the temporary failures, any response except 200 is considered an error
+func Get(url string) (response *http.Response, err error) {
+	err = retry(func() error {
+		response, err = client.Get(url)
+		if err != nil {
+			return err
+		}
+
+		if response.StatusCode != 200 {
+			response.Body.Close()
+
+			return &StatusError{
+				Code:   response.StatusCode,
+				Status: response.Status,
+				URL:    url,
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, Classify(err)
+	}
+
+	return
+}
+
+// retry executes provided function until it succeeds, non-temporary error
+// is returned or all attempts are exhausted, delay between attempts grows exponentially
+func retry(fn func() error) (err error) {
+	delay := Backoff
+	attempts := variables.Retries() + 1
+
+	for attempt := 1; ; attempt++ {
+		err = fn()
+
+		if err == nil || attempt >= attempts || isTemporary(err) == false {
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTemporary checks if it makes sense to retry failed request
+func isTemporary(err error) bool {
+	if statusErr, ok := err.(*StatusError); ok {
+		return statusErr.Code >= 500 || statusErr.Code == 429
+	}
+
+	original := unwrap(err)
+
+	if dnsErr, ok := original.(*net.DNSError); ok {
+		return dnsErr.Temporary() || dnsErr.Timeout()
+	}
+
+	if isTLS(original) {
+		return false
+	}
+
+	return isNetwork(err)
+}
+
+// Classify converts low-level network errors to the human readable ones
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(*StatusError); ok {
+		return err
+	}
+
+	if _, ok := err.(*TimeoutError); ok {
+		return err
+	}
+
+	host := ""
+	if urlErr, ok := err.(*url.Error); ok {
+		if parsed, errParse := url.Parse(urlErr.URL); errParse == nil {
+			host = parsed.Host
+		}
+	}
+
+	original := unwrap(err)
+
+	if dnsErr, ok := original.(*net.DNSError); ok {
+		return errors.New(
+			"Cannot resolve \"" + dnsErr.Name + "\", check your connection or DNS settings",
+		)
+	}
+
+	if isTLS(original) {
+		return errors.New(
+			"Secure connection to \"" + host + "\" cannot be established: " + original.Error(),
+		)
+	}
+
+	if isNetwork(err) {
+		return errors.New(variables.ConnectionError)
+	}
+
+	return err
+}
+
+// isNetwork checks if error happened on the network level
+func isNetwork(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	_, ok := err.(net.Error)
+
+	return ok
+}
+
+// unwrap gets the original error from the url and net errors
+func unwrap(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+
+	return err
+}
+
+// isTLS checks if error happened during the TLS handshake
+func isTLS(err error) bool {
+	switch err.(type) {
+	case x509.UnknownAuthorityError, x509.HostnameError,
+		x509.CertificateInvalidError, tls.RecordHeaderError:
+		return true
+	}
+
+	// Newer versions of Go wrap certificate errors
+	message := err.Error()
+
+	return strings.HasPrefix(message, "tls: ") || strings.HasPrefix(message, "x509: ")
+}
@@ -2,8 +2,17 @@ package request_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 
@@ -17,6 +26,16 @@ import (
 )
 
 var _ = Describe("request", func() {
+	transport := Transport
+
+	BeforeEach(func() {
+		Backoff = 0
+	})
+
+	AfterEach(func() {
+		Backoff = time.Second
+	})
+
 	Describe("Body", func() {
 		var (
 			body string
@@ -49,13 +68,15 @@ var _ = Describe("request", func() {
 
 			BeforeEach(func() {
 				httpmock.Activate()
+				Transport = httpmock.DefaultTransport
 			})
 
 			AfterEach(func() {
-				defer httpmock.DeactivateAndReset()
+				httpmock.DeactivateAndReset()
+				Transport = transport
 			})
 
-			It("should return an error", func() {
+			It("should return an error for server errors", func() {
 				httpmock.RegisterResponder(
 					"GET",
 					"https://somewhere",
@@ -64,13 +85,52 @@ var _ = Describe("request", func() {
 
 				_, err = Body("https://somewhere")
 
-				Expect(err).Should(MatchError(variables.ConnectionError))
+				Expect(err.Error()).To(ContainSubstring("Server error"))
+				Expect(err.Error()).To(ContainSubstring("500"))
+			})
+
+			It("should return an error for client errors", func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://somewhere",
+					httpmock.NewStringResponder(404, ""),
+				)
+
+				_, err = Body("https://somewhere")
+
+				Expect(err.Error()).To(ContainSubstring("Server responded with"))
+				Expect(err.Error()).To(ContainSubstring("404"))
+			})
+
+			It("should retry server errors", func() {
+				attempts := 0
+
+				httpmock.RegisterResponder(
+					"GET",
+					"https://somewhere",
+					func(req *http.Request) (*http.Response, error) {
+						attempts++
+
+						if attempts < 3 {
+							return httpmock.NewStringResponse(503, ""), nil
+						}
+
+						return httpmock.NewStringResponse(200, "yey"), nil
+					},
+				)
+
+				body, err = Body("https://somewhere")
+
+				Expect(err).To(BeNil())
+				Expect(body).To(Equal("yey"))
+				Expect(attempts).To(Equal(3))
 			})
 		})
 
 		Describe("success", func() {
 			BeforeEach(func() {
 				httpmock.Activate()
+				Transport = httpmock.DefaultTransport
 
 				httpmock.RegisterResponder(
 					"GET",
@@ -80,7 +140,8 @@ var _ = Describe("request", func() {
 			})
 
 			AfterEach(func() {
-				defer httpmock.DeactivateAndReset()
+				httpmock.DeactivateAndReset()
+				Transport = transport
 			})
 
 			BeforeEach(func() {
@@ -96,4 +157,220 @@ var _ = Describe("request", func() {
 			})
 		})
 	})
+
+	Describe("Classify", func() {
+		It("should distinguish DNS errors", func() {
+			err := Classify(&url.Error{
+				Op:  "Get",
+				URL: "https://somewhere",
+				Err: &net.OpError{
+					Op:  "dial",
+					Err: &net.DNSError{Name: "somewhere"},
+				},
+			})
+
+			Expect(err.Error()).To(ContainSubstring(`Cannot resolve "somewhere"`))
+		})
+
+		It("should convert other network errors to the connection error", func() {
+			err := Classify(&url.Error{
+				Op:  "Get",
+				URL: "https://somewhere",
+				Err: &net.OpError{
+					Op:  "dial",
+					Err: errors.New("connection refused"),
+				},
+			})
+
+			Expect(err).Should(MatchError(variables.ConnectionError))
+		})
+
+		It("should leave unknown errors as is", func() {
+			err := Classify(errors.New("Weird error"))
+
+			Expect(err).Should(MatchError("Weird error"))
+		})
+	})
+
+	Describe("Download", func() {
+		var (
+			ts      *httptest.Server
+			dir     string
+			path    string
+			content = strings.Repeat("eclectica", 1000)
+			ranges  []string
+		)
+
+		BeforeEach(func() {
+			ranges = []string{}
+
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+
+				if r.URL.Path == "/nope" {
+					w.WriteHeader(404)
+					return
+				}
+
+				if r.URL.Path == "/stall" {
+					w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+					w.Write([]byte(content[:100]))
+					w.(http.Flusher).Flush()
+
+					time.Sleep(200 * time.Millisecond)
+					return
+				}
+
+				http.ServeContent(w, r, "test", time.Now(), strings.NewReader(content))
+			}))
+
+			dir, _ = ioutil.TempDir("", "eclectica-request")
+			path = filepath.Join(dir, "archive.tar.gz")
+		})
+
+		AfterEach(func() {
+			ts.Close()
+			os.RemoveAll(dir)
+		})
+
+		It("should download the whole file", func() {
+			response, err := Download(ts.URL, path)
+			Expect(err).To(BeNil())
+
+			Expect(response.Wait()).To(BeNil())
+			Expect(response.Size).To(Equal(uint64(len(content))))
+
+			data, _ := ioutil.ReadFile(path)
+			Expect(string(data)).To(Equal(content))
+			Expect(ranges[0]).To(Equal(""))
+		})
+
+		It("should continue partially downloaded file", func() {
+			ioutil.WriteFile(path, []byte(content[:100]), 0644)
+
+			response, err := Download(ts.URL, path)
+			Expect(err).To(BeNil())
+
+			Expect(response.Wait()).To(BeNil())
+
+			data, _ := ioutil.ReadFile(path)
+			Expect(string(data)).To(Equal(content))
+			Expect(ranges[0]).To(Equal("bytes=100-"))
+		})
+
+		It("should not download already downloaded file", func() {
+			ioutil.WriteFile(path, []byte(content), 0644)
+
+			response, err := Download(ts.URL, path)
+			Expect(err).To(BeNil())
+
+			Expect(response.Wait()).To(BeNil())
+			Expect(response.IsComplete()).To(Equal(true))
+
+			data, _ := ioutil.ReadFile(path)
+			Expect(string(data)).To(Equal(content))
+		})
+
+		It("should download again if local file does not match the remote one", func() {
+			ioutil.WriteFile(path, []byte(content+"garbage"), 0644)
+
+			response, err := Download(ts.URL, path)
+			Expect(err).To(BeNil())
+
+			Expect(response.Wait()).To(BeNil())
+
+			data, _ := ioutil.ReadFile(path)
+			Expect(string(data)).To(Equal(content))
+			Expect(ranges).To(Equal([]string{"bytes=9007-", ""}))
+		})
+
+		It("should report timeout if server stopped sending the data", func() {
+			os.Setenv("EC_RETRIES", "0")
+			os.Setenv("EC_READ_TIMEOUT", "50ms")
+
+			defer func() {
+				os.Unsetenv("EC_RETRIES")
+				os.Unsetenv("EC_READ_TIMEOUT")
+			}()
+
+			response, err := Download(ts.URL+"/stall", path)
+			Expect(err).To(BeNil())
+
+			err = response.Wait()
+
+			_, ok := err.(*TimeoutError)
+
+			Expect(ok).To(Equal(true))
+			Expect(err.Error()).To(ContainSubstring("No data received for 50ms"))
+		})
+
+		It("should return status error", func() {
+			_, err := Download(ts.URL+"/nope", path)
+
+			statusErr, ok := err.(*StatusError)
+
+			Expect(ok).To(Equal(true))
+			Expect(statusErr.Code).To(Equal(404))
+		})
+	})
+	Describe("mirrors", func() {
+		var (
+			ts            *httptest.Server
+			authorization string
+		)
+
+		BeforeEach(func() {
+			authorization = ""
+
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+
+				w.Write([]byte("<a href=\"v8.9.4/\">v8.9.4/</a>"))
+			}))
+		})
+
+		AfterEach(func() {
+			ts.Close()
+
+			os.Unsetenv("EC_NODE_MIRROR")
+			os.Unsetenv("EC_NODE_MIRROR_TOKEN")
+		})
+
+		It("should use bearer token of the mirror", func() {
+			os.Setenv("EC_NODE_MIRROR", ts.URL+"/dist")
+			os.Setenv("EC_NODE_MIRROR_TOKEN", "secret")
+
+			_, err := Body(ts.URL + "/dist/index.json")
+
+			Expect(err).To(BeNil())
+			Expect(authorization).To(Equal("Bearer secret"))
+		})
+
+		It("should use basic auth defined in the mirror link", func() {
+			link := strings.Replace(ts.URL, "http://", "http://user:pass@", 1)
+			os.Setenv("EC_NODE_MIRROR", link)
+
+			_, err := Body(ts.URL + "/index.json")
+
+			Expect(err).To(BeNil())
+			Expect(authorization).To(Equal("Basic dXNlcjpwYXNz"))
+		})
+
+		It("should not send credentials to other hosts", func() {
+			os.Setenv("EC_NODE_MIRROR", "https://mirror/dist")
+			os.Setenv("EC_NODE_MIRROR_TOKEN", "secret")
+
+			_, err := Body(ts.URL + "/dist")
+
+			Expect(err).To(BeNil())
+			Expect(authorization).To(Equal(""))
+		})
+
+		It("should get HTML document", func() {
+			doc, err := Document(ts.URL)
+
+			Expect(err).To(BeNil())
+			Expect(doc.Find("a").Text()).To(Equal("v8.9.4/"))
+		})
+	})
 })
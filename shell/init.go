@@ -0,0 +1,159 @@
+package shell
+
+import (
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+const (
+	// HookPath is the environment variable with the bin folders added by the hook,
+	// so they could be removed when user leaves the project
+	HookPath = "__EC_HOOK_PATH"
+
+	// HookEnv is the environment variable with the previous values of the variables
+	// defined by the hook, so they could be restored when user leaves the project
+	HookEnv = "__EC_HOOK_ENV"
+
+	posixPath = `export PATH="$(ec path)"
+`
+	fishPath = `set -gx PATH (ec path --shell fish)
+`
+
+	// Bash doesn't have the hook for the directory change,
+	// so we check it before every prompt. Versions of the current
+	// directory might be also switched by "ec" itself, so they are
+	// checked after it too
+	bashHook = `
+_ec_hook() {
+  local status=$?
+
+  if [ "$_EC_PWD" != "$PWD" ]; then
+    _EC_PWD="$PWD"
+    eval "$(command ec hook-env --shell bash)"
+  fi
+
+  return $status
+}
+
+ec() {
+  command ec "$@"
+  local ec_status=$?
+
+  _EC_PWD=""
+
+  return $ec_status
+}
+
+case ";${PROMPT_COMMAND:-};" in
+  *";_ec_hook;"*) ;;
+  *) PROMPT_COMMAND="_ec_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`
+	zshHook = `
+_ec_hook() {
+  eval "$(command ec hook-env --shell zsh)"
+}
+
+ec() {
+  command ec "$@"
+  local ec_status=$?
+
+  _ec_hook
+
+  return $ec_status
+}
+
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd _ec_hook
+_ec_hook
+`
+	fishHook = `
+function _ec_hook --on-variable PWD
+  command ec hook-env --shell fish | source
+end
+
+function ec --wraps ec
+  command ec $argv
+  set -l ec_status $status
+
+  _ec_hook
+
+  return $ec_status
+end
+
+_ec_hook
+`
+)
+
+// Shells are names of the supported shells
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Init gets snippet which activates eclectica in the shell,
+// with auto argument versions are also switched on the directory change
+func Init(name string, auto bool) (string, error) {
+	var result, hook string
+
+	switch name {
+	case "bash":
+		result, hook = posixPath, bashHook
+	case "zsh":
+		result, hook = posixPath, zshHook
+	case "fish":
+		result, hook = fishPath, fishHook
+	default:
+		return "", errors.New(
+			"Shell \"" + name + "\" is not supported, use one of \"" + strings.Join(Shells, "\", \"") + "\"",
+		)
+	}
+
+	if auto {
+		result += hook
+	}
+
+	return result, nil
+}
+
+// Export gets command which defines the environment variable in the provided shell
+func Export(name, key, value string) string {
+	if name != "fish" {
+		return "export " + key + "=" + quote(value) + "\n"
+	}
+
+	// Fish treats the PATH as a list
+	if key == "PATH" {
+		result := "set -gx PATH"
+
+		for _, elem := range strings.Split(value, ":") {
+			if elem != "" {
+				result += " " + quoteFish(elem)
+			}
+		}
+
+		return result + "\n"
+	}
+
+	return "set -gx " + key + " " + quoteFish(value) + "\n"
+}
+
+// Unset gets command which removes the environment variable in the provided shell
+func Unset(name, key string) string {
+	if name == "fish" {
+		return "set -e " + key + "\n"
+	}
+
+	return "unset " + key + "\n"
+}
+
+// quote quotes the value for the POSIX shells
+func quote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// quoteFish quotes the value for the fish shell
+func quoteFish(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "'", `\'`, -1)
+
+	return "'" + value + "'"
+}
@@ -61,15 +61,44 @@ func (shell *Shell) Remove() (err error) {
 	return
 }
 
-// Start starts the shell if needed
+// Start starts the shell if needed and user opted in for it,
+// otherwise it explains how to activate eclectica in the current one
 func (shell *Shell) Start() bool {
-	if shell.shouldRestart {
+	if shell.shouldRestart == false {
+		return false
+	}
+
+	if variables.RestartShell() {
 		return Start()
 	}
 
+	// If output is not a terminal - there is nobody to explain to
+	if terminal.IsTerminal(int(os.Stdout.Fd())) == false {
+		return false
+	}
+
+	print.Warning(
+		"Eclectica is not activated in this shell yet, restart it or execute",
+		activation(Name()),
+	)
+	print.LastPrint()
+
 	return false
 }
 
+// activation gets command which activates eclectica in the current shell
+func activation(name string) string {
+	if name == "fish" {
+		return "ec init fish | source"
+	}
+
+	if name != "zsh" {
+		name = "bash"
+	}
+
+	return `eval "$(ec init ` + name + `)"`
+}
+
 // checkStatus checks the status of the shell
 func (shell *Shell) checkStatus() bool {
 	cmd := Compose(shell.plugins)
@@ -86,7 +115,7 @@ func Compose(plugins []string) (result string) {
 	result = ":" + variables.DefaultInstall
 
 	for _, language := range plugins {
-		result += ":" + filepath.Join(variables.Home(), language, "current/bin")
+		result += ":" + filepath.Join(variables.Path(language), "bin")
 	}
 
 	return
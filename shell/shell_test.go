@@ -38,4 +38,51 @@ var _ = Describe("shell", func() {
 			}
 		})
 	})
+	Describe("Init", func() {
+		It("should activate eclectica", func() {
+			result, err := Init("zsh", false)
+
+			Expect(err).To(BeNil())
+			Expect(result).To(ContainSubstring(`export PATH="$(ec path)"`))
+			Expect(result).NotTo(ContainSubstring("hook-env"))
+		})
+
+		It("should switch versions on directory change", func() {
+			result, _ := Init("fish", true)
+
+			Expect(result).To(ContainSubstring("--on-variable PWD"))
+			Expect(result).To(ContainSubstring("ec hook-env --shell fish | source"))
+		})
+
+		It("should check versions after \"ec\" commands", func() {
+			result, _ := Init("bash", true)
+
+			Expect(result).To(ContainSubstring("ec() {\n  command ec \"$@\""))
+			Expect(result).To(ContainSubstring(`eval "$(command ec hook-env --shell bash)"`))
+		})
+
+		It("should return an error for unknown shell", func() {
+			_, err := Init("tcsh", false)
+
+			Expect(err.Error()).To(ContainSubstring(`Shell "tcsh" is not supported`))
+		})
+	})
+
+	Describe("Export", func() {
+		It("should quote the value", func() {
+			Expect(Export("bash", "A", "it's")).To(Equal("export A='it'\\''s'\n"))
+			Expect(Export("fish", "A", "it's")).To(Equal("set -gx A 'it\\'s'\n"))
+		})
+
+		It("should split the PATH for fish", func() {
+			Expect(Export("fish", "PATH", "/a:/b")).To(Equal("set -gx PATH '/a' '/b'\n"))
+		})
+	})
+
+	Describe("Unset", func() {
+		It("should remove the variable", func() {
+			Expect(Unset("zsh", "A")).To(Equal("unset A\n"))
+			Expect(Unset("fish", "A")).To(Equal("set -e A\n"))
+		})
+	})
 })
@@ -0,0 +1,224 @@
+// Package usage measures disk space taken by the installed versions,
+// their global packages and the leftovers of the installations
+package usage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-errors/errors"
+
+	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/plugins"
+	"github.com/markelog/eclectica/variables"
+)
+
+// Kinds of the entries
+const (
+	Version   = "version"
+	Packages  = "packages"
+	Archive   = "archive"
+	Workspace = "workspace"
+
+	// Linked is the externally built version, it is not measured
+	// since eclectica does not own its prefix
+	Linked = "linked"
+)
+
+// Globs of the global package folders inside of the version folder
+var Globs = map[string][]string{
+	"node":   {"lib/node_modules"},
+	"ruby":   {"lib/ruby/gems"},
+	"python": {"lib/python*/site-packages"},
+}
+
+// Entry is the folder or file which takes the disk space
+type Entry struct {
+	Kind     string `json:"kind"`
+	Language string `json:"language"`
+	Version  string `json:"version"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+}
+
+// Report is the disk usage of the languages
+type Report struct {
+	Total     int64            `json:"total"`
+	Languages map[string]int64 `json:"languages"`
+	Entries   []*Entry         `json:"entries"`
+}
+
+// New measures disk usage of the languages, entries are sorted by size
+func New(languages []string) (*Report, error) {
+	report := &Report{
+		Languages: map[string]int64{},
+		Entries:   []*Entry{},
+	}
+
+	for _, language := range languages {
+		entries, err := Collect(language)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			report.Total += entry.Size
+			report.Languages[language] += entry.Size
+		}
+
+		report.Entries = append(report.Entries, entries...)
+	}
+
+	Sort(report.Entries)
+
+	return report, nil
+}
+
+// Collect gets entries of the language, size of the version
+// does not include its global packages, since they are shown separately
+func Collect(language string) (result []*Entry, err error) {
+	plugin := plugins.New(&plugins.Args{
+		Language: language,
+	})
+
+	for _, version := range plugin.List() {
+		path := variables.Path(language, version)
+
+		if target := variables.LinkTarget(language, version); target != "" {
+			result = append(result, &Entry{
+				Kind:     Linked,
+				Language: language,
+				Version:  version,
+				Path:     target,
+			})
+
+			continue
+		}
+
+		packages, errPackages := packagesOf(language, version, path)
+		if errPackages != nil {
+			return nil, errPackages
+		}
+
+		entry, errEntry := newEntry(Version, language, version, path)
+		if errEntry != nil {
+			return nil, errEntry
+		}
+
+		for _, item := range packages {
+			entry.Size -= item.Size
+		}
+
+		result = append(result, entry)
+		result = append(result, packages...)
+
+		archive := plugins.New(&plugins.Args{
+			Language: language,
+			Version:  version,
+		}).ArchivePath()
+
+		if _, errStat := os.Stat(archive); archive == "" || errStat != nil {
+			continue
+		}
+
+		entry, errEntry = newEntry(Archive, language, version, archive)
+		if errEntry != nil {
+			return nil, errEntry
+		}
+
+		result = append(result, entry)
+	}
+
+	workspaces, err := workspacesOf(language)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, workspaces...)
+
+	return
+}
+
+// Sort sorts entries by size, biggest ones first
+func Sort(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+
+		if entries[i].Language != entries[j].Language {
+			return entries[i].Language < entries[j].Language
+		}
+
+		return entries[i].Version < entries[j].Version
+	})
+}
+
+// packagesOf gets global package folders of the version
+func packagesOf(language, version, path string) (result []*Entry, err error) {
+	for _, glob := range Globs[language] {
+		matches, errGlob := filepath.Glob(filepath.Join(path, glob))
+		if errGlob != nil {
+			return nil, errors.New(errGlob)
+		}
+
+		for _, match := range matches {
+			entry, errEntry := newEntry(Packages, language, version, match)
+			if errEntry != nil {
+				return nil, errEntry
+			}
+
+			result = append(result, entry)
+		}
+	}
+
+	return
+}
+
+// workspacesOf gets build workspaces of the language, including the ones
+// left from the versions which were never installed
+func workspacesOf(language string) (result []*Entry, err error) {
+	folders, err := ioutil.ReadDir(filepath.Join(variables.InstallPath(), language))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, folder := range folders {
+		if folder.IsDir() == false {
+			continue
+		}
+
+		version := folder.Name()
+
+		entry, errEntry := newEntry(Workspace, language, version, variables.InstallLanguage(language, version))
+		if errEntry != nil {
+			return nil, errEntry
+		}
+
+		result = append(result, entry)
+	}
+
+	return
+}
+
+// newEntry creates entry with the size of its path
+func newEntry(kind, language, version, path string) (*Entry, error) {
+	size, err := io.Size(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		Kind:     kind,
+		Language: language,
+		Version:  version,
+		Path:     path,
+		Size:     size,
+	}, nil
+}
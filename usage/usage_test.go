@@ -0,0 +1,128 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/markelog/eclectica/io"
+	. "github.com/markelog/eclectica/usage"
+	"github.com/markelog/eclectica/variables"
+)
+
+var _ = Describe("usage", func() {
+	var home string
+
+	BeforeEach(func() {
+		home = filepath.Join(os.TempDir(), "eclectica-usage-home")
+
+		os.Setenv("EC_HOME", home)
+
+		for _, version := range []string{"6.11.5", "8.9.0"} {
+			os.MkdirAll(filepath.Join(variables.Path("node", version), "lib", "node_modules", "npm"), 0755)
+			variables.WriteVersion("node", version)
+		}
+
+		io.WriteFile(filepath.Join(variables.Path("node", "8.9.0"), "node"), "12345")
+		io.WriteFile(filepath.Join(variables.Path("node", "8.9.0"), "lib", "node_modules", "npm", "index.js"), "1234567890")
+
+		os.MkdirAll(variables.InstallLanguage("node", "9.3.0"), 0755)
+		io.WriteFile(filepath.Join(variables.InstallLanguage("node", "9.3.0"), "Makefile"), "all:")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_HOME")
+		os.RemoveAll(home)
+	})
+
+	Describe("Collect", func() {
+		It("should show global packages separately from the version", func() {
+			entries, err := Collect("node")
+
+			Expect(err).To(BeNil())
+
+			sizes := map[string]int64{}
+			for _, entry := range entries {
+				sizes[entry.Kind+" "+entry.Version] = entry.Size
+			}
+
+			Expect(sizes["version 8.9.0"]).To(Equal(int64(10)))
+			Expect(sizes["packages 8.9.0"]).To(Equal(int64(10)))
+			Expect(sizes["version 6.11.5"]).To(Equal(int64(6)))
+		})
+
+		It("should find build workspaces", func() {
+			entries, _ := Collect("node")
+			last := entries[len(entries)-1]
+
+			Expect(last.Kind).To(Equal(Workspace))
+			Expect(last.Version).To(Equal("9.3.0"))
+			Expect(last.Size).To(Equal(int64(4)))
+		})
+
+		It("should not measure linked versions", func() {
+			prefix := filepath.Join(home, "external")
+
+			os.MkdirAll(filepath.Join(prefix, "lib", "node_modules", "npm"), 0755)
+			io.WriteFile(filepath.Join(prefix, "lib", "node_modules", "npm", "index.js"), "1234567890")
+
+			os.Symlink(prefix, variables.Path("node", "9.3.0-custom"))
+			os.MkdirAll(variables.Links("node"), 0755)
+			io.WriteFile(filepath.Join(variables.Links("node"), "9.3.0-custom"), prefix)
+
+			entries, err := Collect("node")
+
+			Expect(err).To(BeNil())
+
+			for _, entry := range entries {
+				if entry.Version == "9.3.0-custom" {
+					Expect(entry.Kind).To(Equal(Linked))
+					Expect(entry.Size).To(Equal(int64(0)))
+					Expect(entry.Path).To(Equal(prefix))
+				}
+
+				Expect(entry.Size >= 0).To(Equal(true))
+			}
+		})
+
+		It("should not fail for language without versions", func() {
+			entries, err := Collect("rust")
+
+			Expect(err).To(BeNil())
+			Expect(entries).To(BeEmpty())
+		})
+	})
+
+	Describe("New", func() {
+		It("should compose sorted report", func() {
+			report, err := New([]string{"node", "rust"})
+
+			Expect(err).To(BeNil())
+			Expect(report.Total).To(Equal(int64(30)))
+			Expect(report.Languages).To(Equal(map[string]int64{"node": 30}))
+			Expect(report.Entries).To(HaveLen(5))
+
+			for i := 1; i < len(report.Entries); i++ {
+				Expect(report.Entries[i-1].Size >= report.Entries[i].Size).To(Equal(true))
+			}
+		})
+	})
+
+	Describe("Sort", func() {
+		It("should sort by size and then by name", func() {
+			entries := []*Entry{
+				{Language: "ruby", Version: "2.4.0", Size: 1},
+				{Language: "node", Version: "8.9.0", Size: 1},
+				{Language: "node", Version: "6.11.5", Size: 5},
+			}
+
+			Sort(entries)
+
+			Expect(entries[0].Version).To(Equal("6.11.5"))
+			Expect(entries[1].Language).To(Equal("node"))
+			Expect(entries[2].Language).To(Equal("ruby"))
+		})
+	})
+})
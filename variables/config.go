@@ -0,0 +1,316 @@
+package variables
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markelog/eclectica/config"
+)
+
+var (
+	loadSettings sync.Once
+	loaded       *config.Config
+
+	// mirrors is the list of the known mirror names, usually these are the language names,
+	// but if language is downloaded from different places – there would be more of them
+	mirrors = []string{"node", "go", "rust", "python", "ruby", "rvm", "elm"}
+
+	// conventions are environment variables used by the language ecosystem
+	// to define the mirror, these have lower priority then the EC_<NAME>_MIRROR ones
+	conventions = map[string][]string{
+		"node": []string{"NODEJS_ORG_MIRROR", "NVM_NODEJS_ORG_MIRROR"},
+	}
+)
+
+// Settings are taken from the command flags, environment variables,
+// configuration file and the defaults – in that order
+
+// ConfigPath gets path to the eclectica configuration file
+func ConfigPath() string {
+	return filepath.Join(Base(), "config.toml")
+}
+
+// Config gets eclectica configuration
+func Config() (*config.Config, error) {
+	return config.Load(ConfigPath())
+}
+
+// settings gets configuration, malformed configuration is reported by the commands
+func settings() *config.Config {
+	loadSettings.Do(func() {
+		loaded, _ = Config()
+	})
+
+	return loaded
+}
+
+// SystemRoot gets path to the root shared by all users in the system mode, could be
+// defined with EC_SYSTEM_ROOT environment variable, empty value means mode is off
+func SystemRoot() string {
+	root := os.Getenv("EC_SYSTEM_ROOT")
+
+	if root == "" {
+		root = settings().SystemRoot
+	}
+
+	if root == "" {
+		return ""
+	}
+
+	path, err := filepath.Abs(root)
+	if err != nil {
+		return root
+	}
+
+	return path
+}
+
+// IsSystem checks if languages are shared between all users
+func IsSystem() bool {
+	return SystemRoot() != ""
+}
+
+// CacheTTL gets how long list of the remote versions is cached,
+// could be redefined with EC_CACHE_TTL environment variable, zero disables the cache
+func CacheTTL() time.Duration {
+	for _, value := range []string{os.Getenv("EC_CACHE_TTL"), settings().CacheTTL} {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+			return parsed
+		}
+	}
+
+	return 10 * time.Minute
+}
+
+// ConnectTimeout gets timeout for establishing the connection, could be
+// redefined with EC_CONNECT_TIMEOUT environment variable (like "10s" or "10")
+func ConnectTimeout() time.Duration {
+	return duration(30*time.Second, os.Getenv("EC_CONNECT_TIMEOUT"), settings().ConnectTimeout)
+}
+
+// ReadTimeout gets timeout for waiting of the data from the server, could be
+// redefined with EC_READ_TIMEOUT environment variable (like "1m" or "60")
+func ReadTimeout() time.Duration {
+	return duration(60*time.Second, os.Getenv("EC_READ_TIMEOUT"), settings().ReadTimeout)
+}
+
+// Retries gets how many times failed request would be retried, could be
+// redefined with EC_RETRIES environment variable
+func Retries() int {
+	values := []string{os.Getenv("EC_RETRIES")}
+	if settings().Retries != nil {
+		values = append(values, strconv.Itoa(*settings().Retries))
+	}
+
+	for _, value := range values {
+		if retries, err := strconv.Atoi(value); err == nil && retries >= 0 {
+			return retries
+		}
+	}
+
+	return 5
+}
+
+// Jobs gets how many jobs are executed simultaneously when language is compiled,
+// could be redefined with EC_JOBS environment variable
+func Jobs() int {
+	for _, value := range []string{os.Getenv("EC_JOBS"), strconv.Itoa(settings().Jobs)} {
+		if jobs, err := strconv.Atoi(value); err == nil && jobs > 0 {
+			return jobs
+		}
+	}
+
+	return runtime.NumCPU()
+}
+
+// LocalFormat gets format of the dot file for the local versions, "auto" updates
+// the dot file which already defines the version for the folder,
+// could be redefined with EC_LOCAL_FORMAT environment variable
+func LocalFormat() string {
+	for _, value := range []string{os.Getenv("EC_LOCAL_FORMAT"), settings().LocalFormat} {
+		for _, format := range config.LocalFormats {
+			if value == format {
+				return value
+			}
+		}
+	}
+
+	return "auto"
+}
+
+// ProjectRoots gets folders with the projects, which versions should not be pruned,
+// could be redefined with EC_PROJECT_ROOTS environment variable, separated like in the PATH
+func ProjectRoots() (result []string) {
+	value := os.Getenv("EC_PROJECT_ROOTS")
+	if value == "" {
+		value = settings().ProjectRoots
+	}
+
+	for _, root := range filepath.SplitList(value) {
+		if root == "" {
+			continue
+		}
+
+		if root == "~" || strings.HasPrefix(root, "~/") {
+			root = filepath.Join(userHome(), root[1:])
+		}
+
+		result = append(result, root)
+	}
+
+	return
+}
+
+// ProxyPlace gets folder where ec-proxy binary is located,
+// could be redefined with EC_PROXY_PLACE environment variable
+func ProxyPlace() string {
+	if value := os.Getenv("EC_PROXY_PLACE"); value != "" {
+		return value
+	}
+
+	return settings().ProxyPlace
+}
+
+// AutoActivate checks if versions are switched on directory change by the "ec init" snippet,
+// could be redefined with EC_AUTO_ACTIVATE environment variable
+func AutoActivate() bool {
+	return boolean("EC_AUTO_ACTIVATE", settings().AutoActivate)
+}
+
+// AutoInstall checks if proxy installs missing versions defined in the dot files,
+// could be redefined with EC_AUTO_INSTALL environment variable
+func AutoInstall() bool {
+	return boolean("EC_AUTO_INSTALL", settings().AutoInstall)
+}
+
+// RestartShell checks if new shell should be started when eclectica is not activated
+// in the current one, could be redefined with EC_RESTART_SHELL environment variable
+func RestartShell() bool {
+	return boolean("EC_RESTART_SHELL", settings().RestartShell)
+}
+
+// WithModules checks if global modules should be reinstalled from the previous version,
+// could be redefined with EC_WITH_MODULES environment variable
+func WithModules() bool {
+	return boolean("EC_WITH_MODULES", settings().WithModules)
+}
+
+// WithoutSpinner checks if spinners should be disabled,
+// could be redefined with EC_WITHOUT_SPINNER environment variable
+func WithoutSpinner() bool {
+	return boolean("EC_WITHOUT_SPINNER", settings().WithoutSpinner)
+}
+
+// duration gets first positive duration from the provided values,
+// value could be either in seconds or in Go duration format
+func duration(fallback time.Duration, values ...string) time.Duration {
+	for _, value := range values {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+
+	return fallback
+}
+
+// boolean gets boolean value from the environment variable or the fallback
+func boolean(name string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// Mirror gets mirror for the provided name, values from the configuration file
+// are redefined by the environment variables, like EC_NODE_MIRROR, EC_NODE_MIRROR_LIST,
+// EC_NODE_MIRROR_USERNAME, EC_NODE_MIRROR_PASSWORD and EC_NODE_MIRROR_TOKEN
+func Mirror(name string) *config.Mirror {
+	result := &config.Mirror{}
+
+	if mirror, ok := settings().Mirrors[name]; ok && mirror != nil {
+		*result = *mirror
+	}
+
+	for _, env := range conventions[name] {
+		if value := os.Getenv(env); value != "" {
+			result.URL = value
+			break
+		}
+	}
+
+	// Rustup mirrors have the same structure as the "https://static.rust-lang.org"
+	if name == "rust" && os.Getenv("RUSTUP_DIST_SERVER") != "" {
+		result.URL = strings.TrimRight(os.Getenv("RUSTUP_DIST_SERVER"), "/") + "/dist"
+	}
+
+	prefix := "EC_" + strings.ToUpper(strings.Replace(name, "-", "_", -1)) + "_MIRROR"
+
+	setFromEnv(&result.URL, prefix)
+	setFromEnv(&result.List, prefix+"_LIST")
+	setFromEnv(&result.Username, prefix+"_USERNAME")
+	setFromEnv(&result.Password, prefix+"_PASSWORD")
+	setFromEnv(&result.Token, prefix+"_TOKEN")
+
+	result.URL = withoutUser(result.URL, result)
+	result.List = withoutUser(result.List, result)
+
+	return result
+}
+
+// Mirrors gets all known mirrors
+func Mirrors() (result []*config.Mirror) {
+	names := append([]string{}, mirrors...)
+
+	for name := range settings().Mirrors {
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		result = append(result, Mirror(name))
+	}
+
+	return
+}
+
+// setFromEnv sets value from the environment variable if it is defined
+func setFromEnv(value *string, name string) {
+	if env := os.Getenv(name); env != "" {
+		*value = env
+	}
+}
+
+// withoutUser moves user info from the link to the mirror credentials,
+// so they wouldn't leak to the output, and removes trailing slash
+func withoutUser(link string, mirror *config.Mirror) string {
+	link = strings.TrimRight(link, "/")
+
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.User == nil {
+		return link
+	}
+
+	if mirror.Username == "" {
+		mirror.Username = parsed.User.Username()
+		mirror.Password, _ = parsed.User.Password()
+	}
+
+	parsed.User = nil
+
+	return parsed.String()
+}
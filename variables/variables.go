@@ -4,36 +4,31 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/markelog/eclectica/io"
+	"github.com/markelog/eclectica/versions"
 )
 
 var (
 
 	// DefaultInstall is a default path to the general bin folder
-	DefaultInstall = filepath.Join(Base(), "bin")
+	DefaultInstall = filepath.Join(Root(), "bin")
 
 	// ConnectionError is a general connection error text
 	ConnectionError = "Connection cannot be established"
 )
 
-// TempDir gets OS consistent folder path
-// I am crying over here :/
-func TempDir() (tmp string) {
-	tmp = os.TempDir()
-	if runtime.GOOS == "linux" {
-		tmp += "/"
-	}
-
-	return
+// TempDir gets path to the folder for the downloaded archives, it is
+// kept in the support folder, so it is relocated with the EC_HOME as well
+func TempDir() string {
+	return filepath.Join(Support(), "tmp") + string(filepath.Separator)
 }
 
 // IsDebug checks if eclectica in the debug state
 // i.e. will print more info when executing commands
 func IsDebug() bool {
-	return os.Getenv("EC_DEBUG") == "true"
+	return boolean("EC_DEBUG", settings().Debug)
 }
 
 // GetBin returns path to the bin folder of the provided language
@@ -69,16 +64,42 @@ func GetShellPath() string {
 	return path
 }
 
-// Base provides path where eclectica stores everything filesystem related
+// Base provides path where eclectica stores everything filesystem related,
+// could be redefined with EC_HOME environment variable
 func Base() string {
-	usr, _ := user.Current()
-	username := usr.Username
+	if home, ok := os.LookupEnv("EC_HOME"); ok && home != "" {
+		path, err := filepath.Abs(home)
+		if err != nil {
+			return home
+		}
+
+		return path
+	}
+
+	return DefaultBase()
+}
+
+// DefaultBase provides path to the eclectica folder in the user home
+func DefaultBase() string {
+	return filepath.Join(userHome(), ".eclectica")
+}
+
+// userHome gets home folder of the user, even if eclectica is executed with sudo
+func userHome() string {
+	usr, err := user.Current()
+
+	// User might not exist, like in containers with arbitrary UIDs
+	if err != nil {
+		return os.Getenv("HOME")
+	}
 
-	if username == "root" {
-		usr, _ = user.Lookup(os.Getenv("SUDO_USER"))
+	if usr.Username == "root" {
+		if sudoer, errLookup := user.Lookup(os.Getenv("SUDO_USER")); errLookup == nil {
+			usr = sudoer
+		}
 	}
 
-	return filepath.Join(usr.HomeDir, ".eclectica")
+	return usr.HomeDir
 }
 
 // Prefix gets path to the language install folder
@@ -91,17 +112,59 @@ func Prefix(name string) string {
 func Path(args ...interface{}) string {
 	name, version := nameAndVersion(args)
 
+	// Current version is selected by every user for themselves,
+	// even if languages are shared between them
+	if version == "current" {
+		return filepath.Join(Base(), "versions", name, version)
+	}
+
 	return filepath.Join(Home(), name, version)
 }
 
+// Root provides path where languages and everything related to them are stored,
+// it is the shared root in the system mode and eclectica base otherwise
+func Root() string {
+	if IsSystem() {
+		return SystemRoot()
+	}
+
+	return Base()
+}
+
 // Home gets path to the place where eclectica installs their languages
 func Home() string {
-	return filepath.Join(Base(), "versions")
+	return filepath.Join(Root(), "versions")
 }
 
 // Support get path to support folder
 func Support() string {
-	return filepath.Join(Base(), "support")
+	return filepath.Join(Root(), "support")
+}
+
+// Cache get path to cache folder
+func Cache() string {
+	return filepath.Join(Support(), "cache")
+}
+
+// Aliases gets path to the folder with user defined aliases for the language versions,
+// like other personal settings, they are not shared in the system mode
+func Aliases(name string) string {
+	return filepath.Join(Base(), "aliases", name)
+}
+
+// Links gets path to the folder with metadata of the externally built versions,
+// which are linked instead of being installed
+func Links(name string) string {
+	return filepath.Join(Support(), "links", name)
+}
+
+// LinkTarget gets prefix of the externally built version, empty if version is not linked
+func LinkTarget(name, version string) string {
+	if version == "" {
+		return ""
+	}
+
+	return io.Read(filepath.Join(Links(name), version))
 }
 
 // InstallPath get path to install folder
@@ -133,9 +196,24 @@ func nameAndVersion(args []interface{}) (string, string) {
 // CurrentVersion get current version for the specific language
 func CurrentVersion(name string) string {
 	base := Path(name)
+
+	// System version is not installed, so we only point to it
+	if target, err := os.Readlink(base); err == nil && target == versions.System {
+		return versions.System
+	}
+
 	path := filepath.Join(base, ".eclectica")
 
-	return io.Read(path)
+	if version := io.Read(path); version != "" {
+		return version
+	}
+
+	// Linked versions are not modified, so they are only known by the link
+	if target, err := os.Readlink(base); err == nil && LinkTarget(name, filepath.Base(target)) != "" {
+		return filepath.Base(target)
+	}
+
+	return ""
 }
 
 // WriteVersion writes version to the language install folder path
@@ -149,6 +227,14 @@ func WriteVersion(name, version string) error {
 
 // IsInstalled checks if this version was already installed
 func IsInstalled(name, version string) bool {
+	if version == versions.System {
+		return true
+	}
+
+	if LinkTarget(name, version) != "" {
+		return true
+	}
+
 	base := Path(name, version)
 	path := filepath.Join(base, ".eclectica")
 
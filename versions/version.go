@@ -0,0 +1,230 @@
+package versions
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// Every spelling we know of – "1.10rc2" for go, "1.0.0-beta.3" for rust,
+	// "3.7.0b1" for python, "2.0.0-p648" for ruby and "1.10.0-rc2" for us
+	rVersion = regexp.MustCompile(
+		`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-?(alpha|beta|preview|rc|a|b|c|p)(\.)?(\d+)?)?$`,
+	)
+
+	// ranks orders prereleases, release itself has the highest one
+	ranks = map[string]int{
+		"alpha":   1,
+		"a":       1,
+		"beta":    2,
+		"b":       2,
+		"preview": 2,
+		"rc":      3,
+		"c":       3,
+	}
+
+	release = 4
+)
+
+// Version is the parsed language version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+
+	// Pre is the prerelease as it was spelled, like "beta" or "b"
+	Pre string
+
+	// PreNumber is the number of the prerelease, like 2 for "rc2"
+	PreNumber int
+
+	// Level is the ruby patch level, like 648 for "2.0.0-p648",
+	// unlike the prerelease, it goes after the release
+	Level int
+
+	// Spelling details, so the version could be formatted back
+	parts     int
+	preDot    bool
+	hasNumber bool
+	hasLevel  bool
+}
+
+// Parse parses the version in any of the known spellings
+func Parse(value string) (*Version, error) {
+	matches := rVersion.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, errors.New("\"" + value + "\" is not a version")
+	}
+
+	version := &Version{
+		parts: 1,
+	}
+
+	version.Major, _ = strconv.Atoi(matches[1])
+
+	if matches[2] != "" {
+		version.Minor, _ = strconv.Atoi(matches[2])
+		version.parts = 2
+	}
+
+	if matches[3] != "" {
+		version.Patch, _ = strconv.Atoi(matches[3])
+		version.parts = 3
+	}
+
+	number, _ := strconv.Atoi(matches[6])
+
+	if matches[4] == "p" {
+		version.Level = number
+		version.hasLevel = true
+
+		return version, nil
+	}
+
+	if matches[4] != "" {
+		version.Pre = matches[4]
+		version.PreNumber = number
+		version.preDot = matches[5] != ""
+		version.hasNumber = matches[6] != ""
+	}
+
+	return version, nil
+}
+
+// Format formats the version if it could be parsed,
+// otherwise value is returned as is
+func Format(language, value string) string {
+	version, err := Parse(value)
+	if err != nil {
+		return value
+	}
+
+	return version.Format(language)
+}
+
+// String gets the version in the way eclectica stores it, which is semver,
+// except for the spelling of the prerelease, like "1.10.0-rc2" or "1.0.0-beta.3"
+func (version *Version) String() string {
+	return version.numbers(3) + version.prerelease("-") + version.level()
+}
+
+// Format gets the version spelled in the way language upstream does
+func (version *Version) Format(language string) string {
+	switch language {
+	case "go":
+		// Starting from 1.21 release has the patch number, like "1.21.0",
+		// but the prerelease still doesn't, like "1.21rc2"
+		if version.Patch == 0 && (version.Pre != "" || version.Major == 1 && version.Minor < 21) {
+			return version.numbers(2) + version.prerelease("")
+		}
+
+		return version.numbers(3) + version.prerelease("")
+	case "python":
+		// Hats off to inconsistent python developers
+		if version.Patch == 0 && version.Release().LessThan(&Version{Major: 3, Minor: 3}) {
+			return version.numbers(2) + version.prerelease("")
+		}
+
+		return version.numbers(3) + version.prerelease("")
+	}
+
+	return version.String()
+}
+
+// Compare compares versions, returns -1 if version is lower, 0 if they are equal and 1 if it's higher
+func (version *Version) Compare(other *Version) int {
+	pairs := [][2]int{
+		{version.Major, other.Major},
+		{version.Minor, other.Minor},
+		{version.Patch, other.Patch},
+		{version.rank(), other.rank()},
+		{version.PreNumber, other.PreNumber},
+		{version.Level, other.Level},
+	}
+
+	for _, pair := range pairs {
+		if pair[0] < pair[1] {
+			return -1
+		}
+
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// LessThan checks if version is lower then the other one
+func (version *Version) LessThan(other *Version) bool {
+	return version.Compare(other) == -1
+}
+
+// IsPrerelease checks if version is alpha, beta, release candidate and etc
+func (version *Version) IsPrerelease() bool {
+	return version.Pre != ""
+}
+
+// IsPartial checks if version is a mask, like "1" or "1.2", but not "1.10rc2"
+func (version *Version) IsPartial() bool {
+	return version.parts < 3 && version.Pre == ""
+}
+
+// Release gets the version without prerelease and patch level
+func (version *Version) Release() *Version {
+	return &Version{
+		Major: version.Major,
+		Minor: version.Minor,
+		Patch: version.Patch,
+		parts: version.parts,
+	}
+}
+
+// rank gets position of the prerelease, unknown ones are the lowest
+func (version *Version) rank() int {
+	if version.Pre == "" {
+		return release
+	}
+
+	return ranks[version.Pre]
+}
+
+// numbers gets numeric part of the version with at least provided amount of the parts
+func (version *Version) numbers(parts int) string {
+	result := strconv.Itoa(version.Major) + "." + strconv.Itoa(version.Minor)
+
+	if parts > 2 || version.Patch != 0 {
+		result += "." + strconv.Itoa(version.Patch)
+	}
+
+	return result
+}
+
+// prerelease gets the prerelease part with the provided separator
+func (version *Version) prerelease(separator string) string {
+	if version.Pre == "" {
+		return ""
+	}
+
+	result := separator + version.Pre
+
+	if version.preDot {
+		result += "."
+	}
+
+	if version.hasNumber {
+		result += strconv.Itoa(version.PreNumber)
+	}
+
+	return result
+}
+
+// level gets the ruby patch level part
+func (version *Version) level() string {
+	if version.hasLevel == false {
+		return ""
+	}
+
+	return "-p" + strconv.Itoa(version.Level)
+}
@@ -0,0 +1,198 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/markelog/eclectica/versions"
+)
+
+var _ = Describe("Version", func() {
+	Describe("Parse", func() {
+		It("should parse versions of every language", func() {
+			cases := map[string]Version{
+				"1.10rc2":        {Major: 1, Minor: 10, Pre: "rc", PreNumber: 2},
+				"1.21.0":         {Major: 1, Minor: 21},
+				"1.0.0-beta.3":   {Major: 1, Pre: "beta", PreNumber: 3},
+				"3.7.0b1":        {Major: 3, Minor: 7, Pre: "b", PreNumber: 1},
+				"2.7":            {Major: 2, Minor: 7},
+				"2.0.0-p648":     {Major: 2, Level: 648},
+				"2.1.0-preview1": {Major: 2, Minor: 1, Pre: "preview", PreNumber: 1},
+				"v8.1.2":         {Major: 8, Minor: 1, Patch: 2},
+				"1.10.0-rc2":     {Major: 1, Minor: 10, Pre: "rc", PreNumber: 2},
+				"1.5beta":        {Major: 1, Minor: 5, Pre: "beta"},
+				"6":              {Major: 6},
+			}
+
+			for value, expected := range cases {
+				version, err := Parse(value)
+
+				Expect(err).To(BeNil(), value)
+				Expect(version.Major).To(Equal(expected.Major), value)
+				Expect(version.Minor).To(Equal(expected.Minor), value)
+				Expect(version.Patch).To(Equal(expected.Patch), value)
+				Expect(version.Pre).To(Equal(expected.Pre), value)
+				Expect(version.PreNumber).To(Equal(expected.PreNumber), value)
+				Expect(version.Level).To(Equal(expected.Level), value)
+			}
+		})
+
+		It("should return error for things which are not versions", func() {
+			for _, value := range []string{"", "latest", "1.x", "1..2", "node-6", "1.2.3.4", "1.2gamma"} {
+				_, err := Parse(value)
+
+				Expect(err).NotTo(BeNil(), value)
+			}
+		})
+	})
+
+	Describe("String", func() {
+		It("should get the stored spelling", func() {
+			cases := map[string]string{
+				"1.10rc2":        "1.10.0-rc2",
+				"1.10":           "1.10.0",
+				"1.21.0":         "1.21.0",
+				"1.0.0-beta.3":   "1.0.0-beta.3",
+				"3.7.0b1":        "3.7.0-b1",
+				"2.7":            "2.7.0",
+				"2.0.0-p648":     "2.0.0-p648",
+				"2.1.0-preview1": "2.1.0-preview1",
+				"v8.1.2":         "8.1.2",
+			}
+
+			for value, expected := range cases {
+				version, _ := Parse(value)
+
+				Expect(version.String()).To(Equal(expected), value)
+			}
+		})
+	})
+
+	Describe("Format", func() {
+		It("should round-trip upstream spelling", func() {
+			cases := map[string][]string{
+				"go":     {"1.9", "1.10rc2", "1.8.3", "1.21.0", "1.21rc2", "1.22.5"},
+				"python": {"2.7", "2.7.14", "3.2", "3.6.0", "3.7.0b1"},
+				"rust":   {"1.0.0-beta.3", "1.24.0"},
+				"ruby":   {"2.0.0-p648", "2.1.0-preview1", "2.4.1"},
+				"node":   {"6.4.2", "0.10.48"},
+			}
+
+			for language, values := range cases {
+				for _, value := range values {
+					Expect(Format(language, value)).To(Equal(value), language+" "+value)
+				}
+			}
+		})
+
+		It("should format stored spelling for the upstream", func() {
+			cases := map[string][]string{
+				"go":     {"1.10.0-rc2", "1.10rc2"},
+				"python": {"3.7.0-b1", "3.7.0b1"},
+			}
+
+			for language, pair := range cases {
+				Expect(Format(language, pair[0])).To(Equal(pair[1]), language)
+			}
+
+			Expect(Format("go", "1.21.0")).To(Equal("1.21.0"))
+			Expect(Format("go", "1.10.0")).To(Equal("1.10"))
+			Expect(Format("python", "2.7.0")).To(Equal("2.7"))
+			Expect(Format("python", "3.6.0")).To(Equal("3.6.0"))
+		})
+
+		It("should return value as is if it's not a version", func() {
+			Expect(Format("go", "latest")).To(Equal("latest"))
+		})
+	})
+
+	Describe("Compare", func() {
+		It("should order versions", func() {
+			ordered := []string{
+				"1.0.0-alpha",
+				"1.0.0-alpha.2",
+				"1.0.0-beta",
+				"1.0.0-beta.3",
+				"1.0.0-rc1",
+				"1.0.0-rc2",
+				"1.0.0",
+				"1.0.0-p1",
+				"1.0.0-p648",
+				"1.0.1",
+				"1.9",
+				"1.10rc1",
+				"1.10",
+				"2.0.0",
+			}
+
+			for i := range ordered {
+				for j := range ordered {
+					left, _ := Parse(ordered[i])
+					right, _ := Parse(ordered[j])
+
+					expected := 0
+					if i < j {
+						expected = -1
+					}
+					if i > j {
+						expected = 1
+					}
+
+					Expect(left.Compare(right)).To(Equal(expected), ordered[i]+" "+ordered[j])
+				}
+			}
+		})
+
+		It("should treat different spellings of the prerelease as equal", func() {
+			cases := [][]string{
+				{"3.7.0b1", "3.7.0-beta1"},
+				{"3.7.0a1", "3.7.0-alpha1"},
+				{"3.7.0c1", "3.7.0rc1"},
+				{"1.10rc2", "1.10.0-rc2"},
+			}
+
+			for _, pair := range cases {
+				left, _ := Parse(pair[0])
+				right, _ := Parse(pair[1])
+
+				Expect(left.Compare(right)).To(Equal(0), pair[0])
+			}
+		})
+	})
+
+	Describe("IsPrerelease", func() {
+		It("should detect prereleases", func() {
+			cases := map[string]bool{
+				"1.10rc2":      true,
+				"1.0.0-beta.3": true,
+				"3.7.0b1":      true,
+				"2.0.0-p648":   false,
+				"1.10.0":       false,
+			}
+
+			for value, expected := range cases {
+				version, _ := Parse(value)
+
+				Expect(version.IsPrerelease()).To(Equal(expected), value)
+			}
+		})
+	})
+
+	Describe("IsPartial", func() {
+		It("should detect masks", func() {
+			cases := map[string]bool{
+				"1":          true,
+				"1.10":       true,
+				"1.10rc2":    false,
+				"1.10.0":     false,
+				"2.0.0-p648": false,
+			}
+
+			for value, expected := range cases {
+				version, _ := Parse(value)
+
+				Expect(version.IsPartial()).To(Equal(expected), value)
+			}
+		})
+	})
+})
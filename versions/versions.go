@@ -4,14 +4,15 @@ package versions
 
 import (
 	"errors"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/blang/semver"
-	hversion "github.com/hashicorp/go-version"
 )
 
+// System is the version which refers to the language installed
+// by the operating system, not by eclectica
+const System = "system"
+
 // Compose versions to map object of arrays from array
 func Compose(versions []string) map[string][]string {
 	majors := ComposeMajors(versions)
@@ -29,43 +30,29 @@ func Compose(versions []string) map[string][]string {
 
 // ComposeMajors majors version to map object of arrays from array
 func ComposeMajors(versions []string) map[string][]string {
-	result := map[string][]string{}
-	firstPart := regexp.MustCompile("(\\d+)\\.")
-
-	for _, version := range versions {
-		major := firstPart.FindAllStringSubmatch(version, 1)[0][1]
-		major += ".x"
-
-		if _, ok := result[major]; ok == false {
-			result[major] = []string{}
-		}
-
-		result[major] = append(result[major], version)
-	}
-
-	return result
+	return compose(versions, func(version *Version) string {
+		return strconv.Itoa(version.Major) + ".x"
+	})
 }
 
 // ComposeMinors composes minor version to map object of arrays from array
 func ComposeMinors(versions []string) map[string][]string {
+	return compose(versions, func(version *Version) string {
+		return strconv.Itoa(version.Major) + "." + strconv.Itoa(version.Minor) + ".x"
+	})
+}
+
+// compose groups versions by the key, values which are not versions are skipped
+func compose(versions []string, key func(*Version) string) map[string][]string {
 	result := map[string][]string{}
-	firstPart := regexp.MustCompile("(\\d)+\\.(\\d+)")
 
 	for _, version := range versions {
-		checkVersions := firstPart.FindAllStringSubmatch(version, 1)
-
-		// Just in case
-		if len(checkVersions) == 0 {
+		parsed, err := Parse(version)
+		if err != nil {
 			continue
 		}
 
-		versions := checkVersions[0]
-		part := versions[1] + "." + versions[2] + ".x"
-
-		if _, ok := result[part]; ok == false {
-			result[part] = []string{}
-		}
-
+		part := key(parsed)
 		result[part] = append(result[part], version)
 	}
 
@@ -73,51 +60,42 @@ func ComposeMinors(versions []string) map[string][]string {
 }
 
 // GetKeys returns array of keys
-//   map[string][]string{"4.x": []string{}, "0.x": []string{"0.8.2"}}
+//
+//	map[string][]string{"4.x": []string{}, "0.x": []string{"0.8.2"}}
+//
 // gets you:
-// 	 string{"0.x", "4.x"}
+//
+//	string{"4.x", "0.x"}
 func GetKeys(versions map[string][]string) []string {
 	result := []string{}
-	compare := map[string]string{}
-	zeroRe := regexp.MustCompile(`\.x$`)
-
-	for version := range versions {
-		result = append(result, version)
-	}
-
-	// Serialize it to semver list
-	semverVersions := make([]*hversion.Version, len(result))
-	for i, raw := range result {
-		version := zeroRe.ReplaceAllString(raw, ".0-alpha")
-		semverVersion, _ := hversion.NewVersion(version)
+	parsed := map[string]*Version{}
 
-		compare[semverVersion.String()] = raw
-		semverVersions[i] = semverVersion
-	}
-
-	// Sort it
-	sort.Sort(hversion.Collection(semverVersions))
+	for key := range versions {
+		version, err := Parse(strings.TrimSuffix(key, ".x"))
+		if err != nil {
+			continue
+		}
 
-	// Reverse it
-	for i := 0; i < len(semverVersions)/2; i++ {
-		j := len(semverVersions) - i - 1
-		semverVersions[i], semverVersions[j] = semverVersions[j], semverVersions[i]
+		parsed[key] = version
+		result = append(result, key)
 	}
 
-	// Bring it back to normal view
-	for i, version := range semverVersions {
-		result[i] = compare[version.String()]
-	}
+	sort.Slice(result, func(i, j int) bool {
+		return parsed[result[j]].LessThan(parsed[result[i]])
+	})
 
 	return result
 }
 
 // GetElements gets all elements for provided range of version in sorted semver format:
-//   map[string][]string{
-// 		"1.x": string{1.1, 1.1-beta}
-// 	}
+//
+//	  map[string][]string{
+//			"1.x": string{1.1, 1.1-beta}
+//		}
+//
 // Will return:
-//   [1.1.0, 1.1.0-beta]
+//
+//	[1.1.0, 1.1.0-beta]
 func GetElements(key string, versions map[string][]string) []string {
 	for version := range versions {
 		if version == key {
@@ -148,7 +126,16 @@ func IsPartial(version string) bool {
 		return true
 	}
 
-	return len(strings.Split(version, ".")) != 3
+	if version == System {
+		return false
+	}
+
+	parsed, err := Parse(version)
+	if err != nil {
+		return len(strings.Split(version, ".")) != 3
+	}
+
+	return parsed.IsPartial()
 }
 
 // HasMinor checks if provided version has minor info in it
@@ -158,8 +145,12 @@ func HasMinor(version string) bool {
 
 // getLatest gets last possible version from provided map of array strings
 func getLatest(versions map[string][]string) (string, error) {
-	latestVersions := GetKeys(versions)[0]
-	latestList := semverifyList(versions[latestVersions])
+	keys := GetKeys(versions)
+	if len(keys) == 0 {
+		return "", errors.New("No versions available")
+	}
+
+	latestList := semverifyList(versions[keys[0]])
 
 	return latestList[0], nil
 }
@@ -192,51 +183,130 @@ func Latest(version string, versions []string) (string, error) {
 	return result[0], nil
 }
 
-// semverifyList semverifies the list of incomplete versions
-func semverifyList(versions []string) []string {
-	semverList := []semver.Version{}
-	result := []string{}
+// Newest returns newest version from the provided list which matches the mask,
+// like Latest, but prereleases are skipped unless they are allowed
+func Newest(mask string, versions []string, prereleases bool) (string, error) {
+	list := []string{}
 
 	for _, version := range versions {
-		parsed, _ := semver.Parse(Semverify(version))
-		semverList = append(semverList, parsed)
-	}
+		parsed, err := Parse(version)
+		if err != nil {
+			continue
+		}
 
-	semver.Sort(semverList)
+		if parsed.IsPrerelease() && prereleases == false {
+			continue
+		}
 
-	for i := len(semverList) - 1; i != -1; i-- {
-		element := semverList[i]
-		result = append(result, element.String())
+		list = append(list, version)
 	}
 
-	return result
+	if len(list) == 0 {
+		return "", errors.New("No versions available")
+	}
+
+	return Latest(mask, list)
 }
 
-// Semverify will do its best to semverify a string
-// "1.8-beta2" -> "1.8.0-beta2"
-func Semverify(version string) string {
-	if HasMinor(version) == false {
-		return version
+// Match returns all versions from the provided list which match the mask,
+// "6" matches every 6.x version, "6.1" every 6.1.x one and full version only itself
+func Match(mask string, versions []string) (result []string) {
+	result = []string{}
+
+	if mask == "latest" {
+		if highest, err := Highest(versions); err == nil {
+			result = append(result, highest)
+		}
+
+		return
+	}
+
+	if IsPartial(mask) == false {
+		for _, version := range versions {
+			if version == mask {
+				result = append(result, version)
+			}
+		}
+
+		return
+	}
+
+	parsed, err := Parse(mask)
+	if err != nil {
+		return
 	}
 
-	rp, _ := regexp.Compile("[a-z](.+)?")
+	for _, version := range versions {
+		current, err := Parse(version)
+		if err != nil || current.Major != parsed.Major {
+			continue
+		}
+
+		if HasMinor(mask) && current.Minor != parsed.Minor {
+			continue
+		}
+
+		result = append(result, version)
+	}
+
+	return
+}
+
+// Highest returns the highest version from the provided list as it is spelled there,
+// values which are not versions are skipped
+func Highest(versions []string) (string, error) {
+	var (
+		result  string
+		highest *Version
+	)
 
-	if rp.MatchString(version) {
-		start := rp.ReplaceAllString(version, "")
-		end := rp.FindAllString(version, 1)[0]
+	for _, version := range versions {
+		parsed, err := Parse(version)
+		if err != nil {
+			continue
+		}
 
-		return start + ".0-" + end
+		if highest == nil || highest.LessThan(parsed) {
+			result, highest = version, parsed
+		}
 	}
 
-	version += ".0"
+	if highest == nil {
+		return "", errors.New("No versions available")
+	}
+
+	return result, nil
+}
+
+// Line gets the release line of the version, i.e. its major and minor,
+// like "8.9" for "8.9.1" or "1.10" for "1.10.0-rc2"
+func Line(version string) string {
+	parsed, err := Parse(version)
+	if err != nil {
+		return version
+	}
 
-	return version
+	return strconv.Itoa(parsed.Major) + "." + strconv.Itoa(parsed.Minor)
 }
 
-// Unsemverify will do its best to unsemverify a string
-// "1.8.0-beta2" -> "1.8-beta2"
-func Unsemverify(version string) string {
-	rp, _ := regexp.Compile("(\\d+\\.\\d+)\\.0(?:-)?")
+// semverifyList semverifies the list of incomplete versions, newest first
+func semverifyList(versions []string) []string {
+	parsed := []*Version{}
+	result := []string{}
 
-	return rp.ReplaceAllString(version, "$1")
+	for _, version := range versions {
+		if version, err := Parse(version); err == nil {
+			parsed = append(parsed, version)
+		}
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[j].LessThan(parsed[i])
+	})
+
+	for _, version := range parsed {
+		result = append(result, version.String())
+	}
+
+	return result
 }
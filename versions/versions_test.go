@@ -31,6 +31,14 @@ var _ = Describe("versions", func() {
 			Expect(compose["1.4.x"]).To(Equal([]string{"1.4.3"}))
 			Expect(compose["1.5.x"]).To(Equal([]string{"1.5beta1", "1.5beta2", "1.5rc1"}))
 		})
+
+		It("should skip things which are not versions", func() {
+			compose := ComposeMajors([]string{"1", "garbage", "2.2.3"})
+
+			Expect(compose).To(HaveLen(2))
+			Expect(compose["1.x"]).To(Equal([]string{"1"}))
+			Expect(compose["2.x"]).To(Equal([]string{"2.2.3"}))
+		})
 	})
 
 	Describe("GetKeys", func() {
@@ -148,6 +156,56 @@ var _ = Describe("versions", func() {
 		})
 	})
 
+	Describe("Match", func() {
+		versions := []string{"7.2.0", "6.10.1", "6.1.1", "6.1.0", "0.6.0"}
+
+		It("should match every version of the major", func() {
+			Expect(Match("6", versions)).To(Equal([]string{"6.10.1", "6.1.1", "6.1.0"}))
+		})
+
+		It("should match every version of the minor", func() {
+			Expect(Match("6.1", versions)).To(Equal([]string{"6.1.1", "6.1.0"}))
+		})
+
+		It("should match only the full version itself", func() {
+			Expect(Match("6.1.0", versions)).To(Equal([]string{"6.1.0"}))
+			Expect(Match("6.1.2", versions)).To(BeEmpty())
+		})
+
+		It("should match the newest version for 'latest' keyword", func() {
+			Expect(Match("latest", versions)).To(Equal([]string{"7.2.0"}))
+		})
+
+		It("should keep spelling of the newest version for 'latest' keyword", func() {
+			Expect(Match("latest", []string{"3.6.4", "3.7.0b1"})).To(Equal([]string{"3.7.0b1"}))
+		})
+
+		It("should not match anything in the empty list", func() {
+			Expect(Match("latest", nil)).To(BeEmpty())
+			Expect(Match("6", nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("Latest", func() {
+		It("should return error for the empty list", func() {
+			_, err := Latest("latest", []string{})
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Highest", func() {
+		It("should get the highest version as it is spelled", func() {
+			Expect(Highest([]string{"1.9.4", "1.10", "1.10rc2", "nope"})).To(Equal("1.10"))
+		})
+
+		It("should return error if there is no versions", func() {
+			_, err := Highest([]string{"nope"})
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
 	Describe("IsPartial", func() {
 		It("Should return true for 'latest' keyword", func() {
 			Expect(IsPartial("latest")).To(Equal(true))
@@ -164,41 +222,48 @@ var _ = Describe("versions", func() {
 		It("Should return true for full version without minor", func() {
 			Expect(IsPartial("6")).To(Equal(true))
 		})
-	})
 
-	Describe("Semverify", func() {
-		It("Shouldn't do anything for valid version", func() {
-			Expect(Semverify("6.8.1")).To(Equal("6.8.1"))
+		It("Should return false for the system version", func() {
+			Expect(IsPartial("system")).To(Equal(false))
 		})
 
-		It("Should add `.0` to the end there", func() {
-			Expect(Semverify("6.8")).To(Equal("6.8.0"))
+		It("Should return false for the prerelease without patch", func() {
+			Expect(IsPartial("1.10rc2")).To(Equal(false))
 		})
+	})
 
-		It("Should add `.0` to between text and numbers", func() {
-			Expect(Semverify("1.7beta")).To(Equal("1.7.0-beta"))
+	Describe("Newest", func() {
+		versions := []string{"8.9.0", "8.9.4", "8.10.0", "9.0.0-rc1", "9.0.0-rc2", "1.10rc2"}
+
+		It("should get newest version of the line", func() {
+			Expect(Newest("8.9", versions, false)).To(Equal("8.9.4"))
+			Expect(Newest("8", versions, false)).To(Equal("8.10.0"))
 		})
 
-		It("Should add `.0` to between text with numbers and numbers", func() {
-			Expect(Semverify("1.7beta1")).To(Equal("1.7.0-beta1"))
+		It("should skip prereleases", func() {
+			Expect(Newest("latest", versions, false)).To(Equal("8.10.0"))
 		})
-	})
 
-	Describe("Unsemverify", func() {
-		It("Shouldn't do anything for versions without nil patch version", func() {
-			Expect(Unsemverify("6.8.1")).To(Equal("6.8.1"))
+		It("should consider prereleases if they are allowed", func() {
+			Expect(Newest("9", versions, true)).To(Equal("9.0.0-rc2"))
 		})
 
-		It("Should remove `.0` from the end there", func() {
-			Expect(Unsemverify("6.8.0")).To(Equal("6.8"))
+		It("should return error if nothing matches", func() {
+			_, err := Newest("7", versions, false)
+
+			Expect(err).NotTo(BeNil())
 		})
+	})
 
-		It("Should remove `.0` from between text and numbers", func() {
-			Expect(Unsemverify("1.7.0-beta")).To(Equal("1.7beta"))
+	Describe("Line", func() {
+		It("should get major and minor of the version", func() {
+			Expect(Line("8.9.1")).To(Equal("8.9"))
+			Expect(Line("1.10.0-rc2")).To(Equal("1.10"))
+			Expect(Line("2.0.0-p648")).To(Equal("2.0"))
 		})
 
-		It("Should remove `.0` from between text with numbers and numbers", func() {
-			Expect(Unsemverify("1.7.0-beta1")).To(Equal("1.7beta1"))
+		It("should return value as is if it's not a version", func() {
+			Expect(Line("latest")).To(Equal("latest"))
 		})
 	})
 })